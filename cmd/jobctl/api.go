@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/mtr002/Job-Queue/pkg/jobqueue"
+)
+
+// api calls the REST routes for operating the queue that the jobqueue client does not cover
+type api struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// stats mirrors the response of GET /stats
+type stats struct {
+	Total    int                       `json:"total"`
+	ByStatus map[string]int            `json:"by_status"`
+	ByQueue  map[string]map[string]int `json:"by_queue"`
+}
+
+// jobsResponse mirrors the responses listing jobs
+type jobsResponse struct {
+	Jobs  []*jobqueue.Job `json:"jobs"`
+	Count int             `json:"count"`
+}
+
+func newAPI(baseURL string) *api {
+	return &api{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// listJobs calls GET /jobs with the given filters
+func (a *api) listJobs(ctx context.Context, query url.Values) ([]*jobqueue.Job, error) {
+	var resp jobsResponse
+	if err := a.do(ctx, http.MethodGet, "/jobs", query, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Jobs, nil
+}
+
+// jobAction calls POST /jobs/{id}/{action} and returns the job as it was left
+func (a *api) jobAction(ctx context.Context, id, action string) (*jobqueue.Job, error) {
+	job := &jobqueue.Job{}
+	if err := a.do(ctx, http.MethodPost, "/jobs/"+url.PathEscape(id)+"/"+action, nil, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// replayDeadLetters calls POST /dead-letters/replay with the given filters
+func (a *api) replayDeadLetters(ctx context.Context, query url.Values) ([]*jobqueue.Job, error) {
+	var resp jobsResponse
+	if err := a.do(ctx, http.MethodPost, "/dead-letters/replay", query, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Jobs, nil
+}
+
+// stats calls GET /stats
+func (a *api) stats(ctx context.Context) (*stats, error) {
+	s := &stats{}
+	if err := a.do(ctx, http.MethodGet, "/stats", nil, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// updates reads the job updates the API service pushes over /ws
+type updates struct {
+	ctx  context.Context
+	conn *websocket.Conn
+	stop func() bool
+}
+
+// subscribe connects to /ws; the connection is closed once ctx is done
+func (a *api) subscribe(ctx context.Context) (*updates, error) {
+	wsURL, err := url.Parse(a.baseURL + "/ws")
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL: %w", err)
+	}
+	if wsURL.Scheme == "https" {
+		wsURL.Scheme = "wss"
+	} else {
+		wsURL.Scheme = "ws"
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", wsURL, err)
+	}
+
+	// Closing the connection unblocks a pending read once the command is interrupted
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	return &updates{ctx: ctx, conn: conn, stop: stop}, nil
+}
+
+// next blocks until the next job update arrives
+func (u *updates) next() (*jobqueue.Job, error) {
+	for {
+		var message struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := u.conn.ReadJSON(&message); err != nil {
+			if u.ctx.Err() != nil {
+				return nil, u.ctx.Err()
+			}
+			return nil, fmt.Errorf("failed to read update: %w", err)
+		}
+		if message.Type != "job_update" {
+			continue
+		}
+
+		job := &jobqueue.Job{}
+		if err := json.Unmarshal(message.Data, job); err != nil {
+			return nil, fmt.Errorf("failed to decode update: %w", err)
+		}
+		return job, nil
+	}
+}
+
+func (u *updates) close() {
+	u.stop()
+	u.conn.Close()
+}
+
+// do sends a request to the API service and decodes a successful response into out
+func (a *api) do(ctx context.Context, method, path string, query url.Values, out any) error {
+	target := a.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return fmt.Errorf("%s (HTTP %d)", strings.TrimSpace(string(body)), resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mtr002/Job-Queue/pkg/jobqueue"
+)
+
+// newFlagSet creates the flag set of a command; usage describes its arguments
+func newFlagSet(name, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: jobctl %s %s\n", name, usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// runSubmit submits a job whose payload is read from -f, or stdin when -f is missing or "-"
+func runSubmit(ctx context.Context, c *cli, args []string) error {
+	flags := newFlagSet("submit", "-type TYPE [-f FILE] [flags]")
+	jobType := flags.String("type", "", "job type (required)")
+	file := flags.String("f", "-", `file holding the payload, or "-" for stdin`)
+	queue := flags.String("queue", "", "queue to submit to; the job type's default queue if empty")
+	maxAttempts := flags.Int("max-attempts", 0, "attempts before the job fails permanently; the job type's default if zero")
+	expiresIn := flags.Duration("expires-in", 0, "discard the job if it has not started within this long")
+	uniqueKey := flags.String("unique-key", "", "return the job already holding this key instead of submitting another")
+	var priority *int
+	flags.Func("priority", "priority of the job; higher runs first", func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		priority = &n
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *jobType == "" {
+		flags.Usage()
+		return errors.New("submit: -type is required")
+	}
+
+	payload, err := readPayload(*file)
+	if err != nil {
+		return err
+	}
+
+	req := jobqueue.JobRequest{
+		Type:        *jobType,
+		Payload:     payload,
+		Queue:       *queue,
+		MaxAttempts: *maxAttempts,
+		Priority:    priority,
+		UniqueKey:   *uniqueKey,
+	}
+	if *expiresIn > 0 {
+		expiresAt := time.Now().Add(*expiresIn)
+		req.ExpiresAt = &expiresAt
+	}
+
+	job, err := c.jobs.Submit(ctx, req)
+	if err != nil {
+		var payloadErr *jobqueue.PayloadError
+		if errors.As(err, &payloadErr) {
+			for _, field := range payloadErr.Fields {
+				fmt.Fprintf(os.Stderr, "  %s: %s\n", field.Field, field.Message)
+			}
+		}
+		return err
+	}
+	return c.printJob(job)
+}
+
+// readPayload reads a payload file, or stdin for "-", dropping the trailing newline editors and echo add
+func readPayload(file string) (string, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read payload: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func runGet(ctx context.Context, c *cli, args []string) error {
+	flags := newFlagSet("get", "ID")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("get: expected one job ID")
+	}
+
+	job, err := c.jobs.GetJob(ctx, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	return c.printJob(job)
+}
+
+func runList(ctx context.Context, c *cli, args []string) error {
+	flags := newFlagSet("list", "[flags]")
+	status := flags.String("status", "", "only list jobs in this status")
+	jobType := flags.String("type", "", "only list jobs of this type")
+	queue := flags.String("queue", "", "only list jobs in this queue")
+	limit := flags.Int("limit", 50, "list at most this many jobs, newest first; all of them if zero")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	setQuery(query, "status", *status)
+	setQuery(query, "type", *jobType)
+	setQuery(query, "queue", *queue)
+	if *limit > 0 {
+		query.Set("limit", strconv.Itoa(*limit))
+	}
+
+	jobs, err := c.api.listJobs(ctx, query)
+	if err != nil {
+		return err
+	}
+	return c.printJobs(jobs)
+}
+
+// runWatch prints the updates of the given jobs until all of them have finished,
+// or of every job until interrupted when no IDs are given
+func runWatch(ctx context.Context, c *cli, args []string) error {
+	flags := newFlagSet("watch", "[ID...]")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Subscribe first so no update is missed between reading the jobs and watching them
+	updates, err := c.api.subscribe(ctx)
+	if err != nil {
+		return err
+	}
+	defer updates.close()
+
+	watching := make(map[string]bool)
+	for _, id := range flags.Args() {
+		job, err := c.jobs.GetJob(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := c.printUpdate(job); err != nil {
+			return err
+		}
+		if !job.Status.IsTerminal() {
+			watching[id] = true
+		}
+	}
+	if flags.NArg() > 0 && len(watching) == 0 {
+		return nil
+	}
+
+	for {
+		job, err := updates.next()
+		if err != nil {
+			return err
+		}
+		if flags.NArg() > 0 && !watching[job.ID] {
+			continue
+		}
+		if err := c.printUpdate(job); err != nil {
+			return err
+		}
+
+		if flags.NArg() > 0 && job.Status.IsTerminal() {
+			delete(watching, job.ID)
+			if len(watching) == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+func runCancel(ctx context.Context, c *cli, args []string) error {
+	return runJobAction(ctx, c, "cancel", args)
+}
+
+func runRetry(ctx context.Context, c *cli, args []string) error {
+	return runJobAction(ctx, c, "retry", args)
+}
+
+// runJobAction applies a job action to every given job, reporting the ones it failed for and carrying on
+func runJobAction(ctx context.Context, c *cli, action string, args []string) error {
+	flags := newFlagSet(action, "ID...")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("%s: expected at least one job ID", action)
+	}
+
+	var done []*jobqueue.Job
+	failed := 0
+	for _, id := range flags.Args() {
+		job, err := c.api.jobAction(ctx, id, action)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "jobctl: %s %s: %v\n", action, id, err)
+			failed++
+			continue
+		}
+		done = append(done, job)
+	}
+
+	if err := c.printJobs(done); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d jobs", action, failed, flags.NArg())
+	}
+	return nil
+}
+
+func runReplay(ctx context.Context, c *cli, args []string) error {
+	flags := newFlagSet("replay", "[flags]")
+	jobType := flags.String("type", "", "only replay jobs of this type")
+	queue := flags.String("queue", "", "only replay jobs in this queue")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	setQuery(query, "type", *jobType)
+	setQuery(query, "queue", *queue)
+
+	jobs, err := c.api.replayDeadLetters(ctx, query)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Replayed %d jobs\n", len(jobs))
+	return c.printJobs(jobs)
+}
+
+func runStats(ctx context.Context, c *cli, args []string) error {
+	flags := newFlagSet("stats", "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	s, err := c.api.stats(ctx)
+	if err != nil {
+		return err
+	}
+	return c.printStats(s)
+}
+
+// setQuery adds a filter to a query only when it was given
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
@@ -0,0 +1,117 @@
+// Command jobctl operates the job queue through the REST API of the API service
+// and, for submitting and reading jobs, optionally the gRPC API of the worker service.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mtr002/Job-Queue/pkg/jobqueue"
+)
+
+const usageText = `Usage: jobctl [flags] <command> [arguments]
+
+Commands:
+  submit   submit a job with its payload read from a file or stdin
+  get      show a job
+  list     list jobs, optionally filtered by status, type and queue
+  watch    follow jobs until they finish
+  cancel   cancel jobs that have not started running
+  retry    send failed, expired or cancelled jobs back to pending
+  replay   retry every permanently failed job, optionally of one type or queue
+  stats    count jobs per status and queue
+
+Flags:
+`
+
+// command is a jobctl subcommand; args are the arguments following its name
+type command func(ctx context.Context, c *cli, args []string) error
+
+var commands = map[string]command{
+	"submit": runSubmit,
+	"get":    runGet,
+	"list":   runList,
+	"watch":  runWatch,
+	"cancel": runCancel,
+	"retry":  runRetry,
+	"replay": runReplay,
+	"stats":  runStats,
+}
+
+// cli holds what every command needs: the API clients and where and how to print
+type cli struct {
+	api    *api
+	jobs   jobqueue.Client
+	out    io.Writer
+	format outputFormat
+}
+
+func main() {
+	flags := flag.NewFlagSet("jobctl", flag.ExitOnError)
+	server := flags.String("server", envOr("JOBCTL_SERVER", "http://localhost:8080"), "URL of the API service")
+	grpcAddr := flags.String("grpc", os.Getenv("JOBCTL_GRPC"), "address of the worker gRPC service; submit and get use it instead of REST when set")
+	output := flags.String("o", "table", "output format: table, json or yaml")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usageText)
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	run, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "jobctl: unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	format, err := parseOutputFormat(*output)
+	if err != nil {
+		fatal(err)
+	}
+
+	var client jobqueue.Client = jobqueue.NewHTTPClient(*server)
+	if *grpcAddr != "" {
+		client, err = jobqueue.NewGRPCClient(*grpcAddr)
+		if err != nil {
+			fatal(fmt.Errorf("failed to connect to %s: %w", *grpcAddr, err))
+		}
+	}
+	defer client.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c := &cli{api: newAPI(*server), jobs: client, out: os.Stdout, format: format}
+	err = run(ctx, c, flags.Args()[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		stop()
+		client.Close()
+		fatal(err)
+	}
+}
+
+// envOr returns the environment variable key, or fallback when it is not set
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "jobctl:", err)
+	os.Exit(1)
+}
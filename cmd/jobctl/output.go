@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.yaml.in/yaml/v2"
+
+	"github.com/mtr002/Job-Queue/pkg/jobqueue"
+)
+
+// outputFormat is how commands print their results
+type outputFormat string
+
+const (
+	formatTable outputFormat = "table"
+	formatJSON  outputFormat = "json"
+	formatYAML  outputFormat = "yaml"
+)
+
+func parseOutputFormat(name string) (outputFormat, error) {
+	switch format := outputFormat(name); format {
+	case formatTable, formatJSON, formatYAML:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown output format %q: must be table, json or yaml", name)
+}
+
+// print writes v as JSON or YAML, or as the table the given function renders
+func (c *cli) print(v any, table func(w io.Writer)) error {
+	switch c.format {
+	case formatJSON:
+		encoder := json.NewEncoder(c.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case formatYAML:
+		return writeYAML(c.out, v)
+	}
+
+	tw := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	table(tw)
+	return tw.Flush()
+}
+
+// printUpdate writes one job of a stream of updates: a table row, a JSON line or a YAML document
+func (c *cli) printUpdate(job *jobqueue.Job) error {
+	switch c.format {
+	case formatJSON:
+		return json.NewEncoder(c.out).Encode(job)
+	case formatYAML:
+		fmt.Fprintln(c.out, "---")
+		return writeYAML(c.out, job)
+	}
+
+	line := fmt.Sprintf("%s  %s  %s", job.UpdatedAt.Local().Format(time.TimeOnly), job.ID, job.Status)
+	if job.Status == jobqueue.StatusProcessing {
+		line += "  " + formatProgress(job)
+	}
+	_, err := fmt.Fprintln(c.out, line)
+	return err
+}
+
+func (c *cli) printJob(job *jobqueue.Job) error {
+	return c.print(job, func(w io.Writer) {
+		field := func(name, value string) {
+			if value != "" {
+				fmt.Fprintf(w, "%s:\t%s\n", name, value)
+			}
+		}
+		field("ID", job.ID)
+		field("Type", job.Type)
+		field("Queue", job.Queue)
+		field("Status", string(job.Status))
+		field("Priority", fmt.Sprint(job.Priority))
+		field("Attempts", fmt.Sprintf("%d/%d", job.Attempts, job.MaxAttempts))
+		if job.Status == jobqueue.StatusProcessing {
+			field("Progress", formatProgress(job))
+		}
+		field("Batch", job.BatchID)
+		field("Workflow", job.WorkflowID)
+		field("Ordering key", job.OrderingKey)
+		field("Unique key", job.UniqueKey)
+		field("Retry after", formatTime(job.RetryAfter))
+		field("Expires at", formatTime(job.ExpiresAt))
+		field("Created", formatTime(&job.CreatedAt))
+		field("Updated", formatTime(&job.UpdatedAt))
+		field("Payload", job.Payload)
+		field("Result", job.Result)
+		field("Error", job.Error)
+	})
+}
+
+func (c *cli) printJobs(jobs []*jobqueue.Job) error {
+	if jobs == nil {
+		jobs = []*jobqueue.Job{}
+	}
+	return c.print(jobs, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tTYPE\tQUEUE\tSTATUS\tATTEMPTS\tPRIORITY\tUPDATED")
+		for _, job := range jobs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d/%d\t%d\t%s\n",
+				job.ID, job.Type, job.Queue, job.Status, job.Attempts, job.MaxAttempts, job.Priority, formatTime(&job.UpdatedAt))
+		}
+	})
+}
+
+func (c *cli) printStats(s *stats) error {
+	return c.print(s, func(w io.Writer) {
+		fmt.Fprintln(w, "QUEUE\tSTATUS\tCOUNT")
+		for _, queue := range sortedKeys(s.ByQueue) {
+			counts := s.ByQueue[queue]
+			for _, status := range sortedKeys(counts) {
+				fmt.Fprintf(w, "%s\t%s\t%d\n", queue, status, counts[status])
+			}
+		}
+		for _, status := range sortedKeys(s.ByStatus) {
+			fmt.Fprintf(w, "(all)\t%s\t%d\n", status, s.ByStatus[status])
+		}
+		fmt.Fprintf(w, "(all)\t(all)\t%d\n", s.Total)
+	})
+}
+
+// writeYAML renders v as YAML. Going through JSON keeps the field names and omissions of the json tags.
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	out, err := yaml.Marshal(generic)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+func formatProgress(job *jobqueue.Job) string {
+	return strings.TrimSpace(fmt.Sprintf("%d%% %s", job.Progress, job.ProgressMessage))
+}
+
+// formatTime renders an optional timestamp in local time, or empty when unset
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
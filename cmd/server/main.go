@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/mtr002/Job-Queue/internal/api"
+	"github.com/mtr002/Job-Queue/internal/config"
 	"github.com/mtr002/Job-Queue/internal/db"
 	"github.com/mtr002/Job-Queue/internal/grpc"
 	"github.com/mtr002/Job-Queue/internal/interfaces"
@@ -18,38 +23,55 @@ import (
 )
 
 func main() {
-	const (
-		port          = "8080"
-		workerAddr    = "localhost:8081"
-		migrationsDir = "migrations"
-	)
-
 	logger.Init("api-service")
+
+	cfg, err := config.Load("server", os.Args[1:])
+	if errors.Is(err, config.ErrPrinted) || errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to load configuration")
+	}
+	if err := logger.SetLevel(cfg.Log.Level); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to set log level")
+	}
+
+	if len(cfg.Args) > 0 {
+		if cfg.Args[0] != "migrate" {
+			logger.Logger.Fatal().Str("command", cfg.Args[0]).Msg("Unknown command")
+		}
+		if err := db.RunMigrateCommand(cfg.DB(), cfg.Args[1:]); err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Migration failed")
+		}
+		return
+	}
+
 	logger.Logger.Info().Msg("Starting API Service with gRPC and WebSocket")
 
-	config := db.DefaultConfig()
-	database, err := db.Connect(config)
+	database, err := db.Connect(cfg.DB())
 	if err != nil {
 		logger.Logger.Fatal().Err(err).Msg("Failed to connect to database")
 	}
 	defer database.Close()
 
-	if err := db.RunMigrations(database, migrationsDir); err != nil {
-		logger.Logger.Fatal().Err(err).Msg("Failed to run migrations")
+	if cfg.AutoMigrate {
+		if err := db.RunMigrations(database); err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to run migrations")
+		}
 	}
 
+	// Only the log level can change without a restart, which the reloader applies itself
+	reloader := config.NewReloader("server", os.Args[1:], cfg, func(*config.Config) error { return nil })
+	reloader.WatchSignals()
+
 	store := db.NewStore(database)
-	manager := jobs.NewManager(store, 3)
+	manager := jobs.NewManager(store, cfg.Jobs.DefaultMaxAttempts)
 
 	var grpcClient *grpc.Client
 	var natsClient *nats.Client
 
-	useNATS := os.Getenv("USE_NATS")
-	if useNATS == "true" {
-		natsURL := os.Getenv("NATS_URL")
-		if natsURL == "" {
-			natsURL = "nats://localhost:4222"
-		}
+	if cfg.NATS.Enabled {
+		natsURL := cfg.NATS.URL
 		client, err := nats.NewClient(natsURL)
 		if err != nil {
 			logger.Logger.Fatal().Err(err).Msg("Failed to connect to NATS")
@@ -58,13 +80,13 @@ func main() {
 		natsClient = client
 		logger.Logger.Info().Str("url", natsURL).Msg("Using NATS for job submission")
 	} else {
-		client, err := grpc.NewClient(workerAddr)
+		client, err := grpc.NewClient(cfg.API.WorkerAddr)
 		if err != nil {
 			logger.Logger.Fatal().Err(err).Msg("Failed to connect to Worker Service")
 		}
 		defer client.Close()
 		grpcClient = client
-		logger.Logger.Info().Str("addr", workerAddr).Msg("Using gRPC for job submission")
+		logger.Logger.Info().Str("addr", cfg.API.WorkerAddr).Msg("Using gRPC for job submission")
 	}
 
 	hub := websocket.NewHub()
@@ -72,7 +94,7 @@ func main() {
 
 	go startJobPoller(manager, hub)
 
-	server := api.NewServer(manager, grpcClient, natsClient, hub, port, database)
+	server := api.NewServer(manager, grpcClient, natsClient, hub, cfg.API.Port, database)
 
 	go func() {
 		server.Start()
@@ -82,7 +104,12 @@ func main() {
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	<-sigChan
 
-	logger.Logger.Info().Msg("Shutting down gracefully...")
+	logger.Logger.Info().Dur("timeout", cfg.API.ShutdownTimeout).Msg("Shutting down gracefully...")
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.API.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Logger.Warn().Err(err).Msg("Requests were still in flight at the shutdown deadline")
+	}
 	logger.Logger.Info().Msg("API Service stopped")
 }
 
@@ -104,13 +131,23 @@ func startJobPoller(manager *jobs.Manager, hub *websocket.Hub) {
 				pendingCount++
 			}
 
-			lastStatus, exists := lastJobs[job.ID]
-			if !exists || lastStatus != string(job.Status) {
+			// Progress reports change the job without changing its status, so they are compared too
+			state := fmt.Sprintf("%s:%d:%s", job.Status, job.Progress, job.ProgressMessage)
+			lastState, exists := lastJobs[job.ID]
+			if !exists || lastState != state {
 				websocket.BroadcastJobUpdate(hub, job)
-				lastJobs[job.ID] = string(job.Status)
+				lastJobs[job.ID] = state
 			}
 		}
 
 		metrics.PendingJobs.Set(float64(pendingCount))
+
+		// Listing queue states, rate limits and workers refreshes their gauges, picking up changes made by other services
+		manager.GetQueueStates()
+		manager.GetRateLimits()
+		manager.ListWorkers()
+
+		// Worker services look for dead peers themselves, but someone has to once the last of them is gone
+		manager.DetectDeadWorkers()
 	}
 }
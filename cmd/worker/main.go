@@ -2,15 +2,26 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/mtr002/Job-Queue/internal/config"
 	"github.com/mtr002/Job-Queue/internal/db"
+	"github.com/mtr002/Job-Queue/internal/interfaces"
 	"github.com/mtr002/Job-Queue/internal/jobs"
 	"github.com/mtr002/Job-Queue/internal/logger"
 	"github.com/mtr002/Job-Queue/internal/nats"
@@ -18,21 +29,80 @@ import (
 	"github.com/mtr002/Job-Queue/proto"
 )
 
+// Version is reported to the worker registry; set at build time with -ldflags "-X main.Version=..."
+var Version = "dev"
+
 type workerServer struct {
 	proto.UnimplementedWorkerServiceServer
 	manager *jobs.Manager
 }
 
-func (s *workerServer) SubmitJob(ctx context.Context, req *proto.SubmitJobRequest) (*proto.SubmitJobResponse, error) {
-	maxAttempts := int(req.MaxAttempts)
-	if maxAttempts <= 0 {
-		maxAttempts = 3
+// jobRequestFromProto converts a wire submission into a job request, rejecting malformed fields
+func jobRequestFromProto(req *proto.SubmitJobRequest) (jobs.JobRequest, error) {
+	expiresAt, err := parseTimestamp(req.ExpiresAt)
+	if err != nil {
+		return jobs.JobRequest{}, fmt.Errorf("invalid expires_at: %w", err)
 	}
 
-	job, err := s.manager.SubmitJob(req.Type, req.Payload)
+	return jobs.JobRequest{
+		Type:                req.Type,
+		Payload:             req.Payload,
+		Queue:               req.Queue,
+		MaxAttempts:         int(req.MaxAttempts),
+		DependsOn:           req.DependsOn,
+		DependencyPolicy:    interfaces.DependencyPolicy(req.DependencyPolicy),
+		CompensationType:    req.CompensationType,
+		CompensationPayload: req.CompensationPayload,
+		UniqueKey:           req.UniqueKey,
+		UniqueScope:         interfaces.UniqueScope(req.UniqueScope),
+		UniqueWindow:        req.UniqueWindow,
+		OnDuplicate:         jobs.DuplicatePolicy(req.OnDuplicate),
+		OrderingKey:         req.OrderingKey,
+		ExpiresAt:           expiresAt,
+		Priority:            optionalInt(req.Priority),
+	}, nil
+}
+
+// optionalInt converts an optional wire integer, keeping it unset when it was not sent
+func optionalInt(n *int32) *int {
+	if n == nil {
+		return nil
+	}
+	v := int(*n)
+	return &v
+}
+
+// parseTimestamp reads an optional RFC3339 timestamp, treating an empty value as unset
+func parseTimestamp(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
 	if err != nil {
 		return nil, err
 	}
+	return &t, nil
+}
+
+// formatTimestamp renders an optional timestamp as RFC3339, or empty when unset
+func formatTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (s *workerServer) SubmitJob(ctx context.Context, req *proto.SubmitJobRequest) (*proto.SubmitJobResponse, error) {
+	// Missing max attempts come from the job type, falling back to the manager's default
+	jobReq, err := jobRequestFromProto(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	job, err := s.manager.Submit(jobReq)
+	if err != nil {
+		return nil, submitError(err)
+	}
 
 	return &proto.SubmitJobResponse{
 		JobId:     job.ID,
@@ -41,28 +111,316 @@ func (s *workerServer) SubmitJob(ctx context.Context, req *proto.SubmitJobReques
 	}, nil
 }
 
+// submitJobsChunkSize is how many streamed jobs are buffered before they are inserted
+const submitJobsChunkSize = 1000
+
+func (s *workerServer) SubmitJobs(stream proto.WorkerService_SubmitJobsServer) error {
+	resp := &proto.SubmitJobsResponse{}
+	chunk := make([]jobs.JobRequest, 0, submitJobsChunkSize)
+	offset := 0
+
+	flush := func() {
+		if len(chunk) == 0 {
+			return
+		}
+		for _, result := range s.manager.SubmitJobs(chunk) {
+			resp.Results = append(resp.Results, &proto.SubmitJobsResult{
+				Index: int32(offset + result.Index),
+				JobId: result.ID,
+				Error: result.Error,
+			})
+			if result.Error != "" {
+				resp.Rejected++
+			} else {
+				resp.Accepted++
+			}
+		}
+		offset += len(chunk)
+		chunk = chunk[:0]
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		jobReq, err := jobRequestFromProto(req)
+		if err != nil {
+			// Results stay in submission order, so the jobs before the malformed one go in first
+			flush()
+			resp.Results = append(resp.Results, &proto.SubmitJobsResult{Index: int32(offset), Error: err.Error()})
+			resp.Rejected++
+			offset++
+			continue
+		}
+
+		chunk = append(chunk, jobReq)
+		if len(chunk) == submitJobsChunkSize {
+			flush()
+		}
+	}
+	flush()
+
+	return stream.SendAndClose(resp)
+}
+
 func (s *workerServer) GetJobStatus(ctx context.Context, req *proto.GetJobRequest) (*proto.JobStatusResponse, error) {
 	job, err := s.manager.GetJob(req.JobId)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
 	resp := &proto.JobStatusResponse{
 		JobId:       job.ID,
 		Type:        job.Type,
 		Status:      string(job.Status),
+		Queue:       job.Queue,
+		UniqueKey:   job.UniqueKey,
+		OrderingKey: job.OrderingKey,
+		ExpiresAt:   formatTimestamp(job.ExpiresAt),
 		Payload:     job.Payload,
 		Result:      job.Result,
 		Error:       job.Error,
 		Attempts:    int32(job.Attempts),
 		MaxAttempts: int32(job.MaxAttempts),
+		Priority:    int32(job.Priority),
 		CreatedAt:   job.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   job.UpdatedAt.Format(time.RFC3339),
+
+		CompensationStatus: string(job.CompensationStatus),
+		CompensationJobId:  job.CompensationJobID,
+
+		Progress:        int32(job.Progress),
+		ProgressMessage: job.ProgressMessage,
+		Checkpoint:      job.Checkpoint,
+	}
+
+	return resp, nil
+}
+
+func (s *workerServer) SubmitWorkflow(ctx context.Context, req *proto.SubmitWorkflowRequest) (*proto.WorkflowStatusResponse, error) {
+	def := jobs.WorkflowDefinition{
+		Name:   req.Name,
+		Output: req.Output,
+	}
+	for _, step := range req.Steps {
+		def.Steps = append(def.Steps, jobs.WorkflowStepDefinition{
+			Name:        step.Name,
+			Type:        step.Type,
+			Payload:     step.Payload,
+			Queue:       step.Queue,
+			MaxAttempts: int(step.MaxAttempts),
+			DependsOn:   step.DependsOn,
+			InputFrom:   step.InputFrom,
+
+			CompensationType:    step.CompensationType,
+			CompensationPayload: step.CompensationPayload,
+		})
+	}
+
+	workflow, err := s.manager.SubmitWorkflow(def)
+	if err != nil {
+		if errors.Is(err, jobs.ErrInvalidWorkflow) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, submitError(err)
+	}
+
+	return workflowToProto(workflow), nil
+}
+
+// submitError maps a submission error to its gRPC status
+func submitError(err error) error {
+	var payloadErr *jobs.PayloadError
+	switch {
+	case errors.As(err, &payloadErr):
+		// The offending fields travel as details so clients can report them one by one
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range payloadErr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		st, detailErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(badRequest)
+		if detailErr != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return st.Err()
+	case errors.Is(err, jobs.ErrInvalidJob):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, jobs.ErrQueueDraining):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, jobs.ErrDuplicateJob):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return err
+}
+
+func (s *workerServer) PauseQueue(ctx context.Context, req *proto.QueueControlRequest) (*proto.QueueState, error) {
+	return s.setQueueState(req, interfaces.QueuePaused)
+}
+
+func (s *workerServer) ResumeQueue(ctx context.Context, req *proto.QueueControlRequest) (*proto.QueueState, error) {
+	return s.setQueueState(req, interfaces.QueueActive)
+}
+
+func (s *workerServer) DrainQueue(ctx context.Context, req *proto.QueueControlRequest) (*proto.QueueState, error) {
+	return s.setQueueState(req, interfaces.QueueDraining)
+}
+
+// setQueueState applies a queue control RPC; kind defaults to a queue
+func (s *workerServer) setQueueState(req *proto.QueueControlRequest, state interfaces.QueueControlState) (*proto.QueueState, error) {
+	kind := interfaces.QueueControlKind(req.Kind)
+	if kind == "" {
+		kind = interfaces.QueueControlQueue
+	}
+
+	queueState, err := s.manager.SetQueueState(kind, req.Name, state, req.Reason)
+	if err != nil {
+		if errors.Is(err, jobs.ErrInvalidQueueState) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	return queueStateToProto(queueState), nil
+}
+
+func (s *workerServer) ListQueueStates(ctx context.Context, req *proto.ListQueueStatesRequest) (*proto.ListQueueStatesResponse, error) {
+	states, err := s.manager.GetQueueStates()
+	if err != nil {
+		return nil, err
 	}
 
+	resp := &proto.ListQueueStatesResponse{}
+	for _, state := range states {
+		resp.States = append(resp.States, queueStateToProto(state))
+	}
 	return resp, nil
 }
 
+// queueStateToProto converts a queue state into its wire form
+func queueStateToProto(state *interfaces.QueueState) *proto.QueueState {
+	return &proto.QueueState{
+		Kind:      string(state.Kind),
+		Name:      state.Name,
+		State:     string(state.State),
+		Reason:    state.Reason,
+		UpdatedAt: state.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *workerServer) SetTypeLimit(ctx context.Context, req *proto.SetTypeLimitRequest) (*proto.TypeLimit, error) {
+	limit, err := s.manager.SetTypeLimit(req.Type, int(req.MaxConcurrency))
+	if err != nil {
+		if errors.Is(err, jobs.ErrInvalidTypeLimit) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	return typeLimitToProto(limit), nil
+}
+
+func (s *workerServer) ListTypeLimits(ctx context.Context, req *proto.ListTypeLimitsRequest) (*proto.ListTypeLimitsResponse, error) {
+	limits, err := s.manager.GetTypeLimits()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.ListTypeLimitsResponse{}
+	for _, limit := range limits {
+		resp.Limits = append(resp.Limits, typeLimitToProto(limit))
+	}
+	return resp, nil
+}
+
+// typeLimitToProto converts a type limit into its wire form
+func typeLimitToProto(limit *interfaces.TypeLimit) *proto.TypeLimit {
+	return &proto.TypeLimit{
+		Type:           limit.Type,
+		MaxConcurrency: int32(limit.MaxConcurrency),
+		Running:        int32(limit.Running),
+		UpdatedAt:      limit.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *workerServer) StreamJobLogs(req *proto.StreamJobLogsRequest, stream proto.WorkerService_StreamJobLogsServer) error {
+	send := func(entry *interfaces.JobLog) error {
+		return stream.Send(jobLogToProto(entry))
+	}
+
+	if req.Follow {
+		return s.manager.FollowJobLogs(stream.Context(), req.JobId, req.AfterId, send)
+	}
+
+	logs, err := s.manager.GetJobLogs(req.JobId, req.AfterId)
+	if err != nil {
+		return err
+	}
+	for _, entry := range logs {
+		if err := send(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jobLogToProto converts a job log line into its wire form
+func jobLogToProto(entry *interfaces.JobLog) *proto.JobLogEntry {
+	return &proto.JobLogEntry{
+		Id:        entry.ID,
+		JobId:     entry.JobID,
+		Attempt:   int32(entry.Attempt),
+		Level:     entry.Level,
+		Message:   entry.Message,
+		CreatedAt: entry.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *workerServer) GetWorkflow(ctx context.Context, req *proto.GetWorkflowRequest) (*proto.WorkflowStatusResponse, error) {
+	workflow, err := s.manager.GetWorkflow(req.WorkflowId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return workflowToProto(workflow), nil
+}
+
+// workflowToProto converts a workflow and its steps into the wire status response
+func workflowToProto(workflow *interfaces.Workflow) *proto.WorkflowStatusResponse {
+	resp := &proto.WorkflowStatusResponse{
+		WorkflowId: workflow.ID,
+		Name:       workflow.Name,
+		Status:     string(workflow.Status),
+		OutputStep: workflow.OutputStep,
+		Output:     workflow.Output,
+		Error:      workflow.Error,
+		CreatedAt:  workflow.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  workflow.UpdatedAt.Format(time.RFC3339),
+	}
+
+	for _, step := range workflow.Steps {
+		resp.Steps = append(resp.Steps, &proto.WorkflowStepStatus{
+			Name:      step.Name,
+			JobId:     step.JobID,
+			Type:      step.Type,
+			DependsOn: step.DependsOn,
+			InputFrom: step.InputFrom,
+			Status:    string(step.Status),
+			Result:    step.Result,
+			Error:     step.Error,
+		})
+	}
+
+	return resp
+}
+
 func (s *workerServer) NotifyJobCompleted(ctx context.Context, req *proto.ProcessJobRequest) (*proto.ProcessJobResponse, error) {
 	job, err := s.manager.GetJob(req.JobId)
 	if err != nil {
@@ -107,42 +465,127 @@ func (s *workerServer) NotifyJobFailed(ctx context.Context, req *proto.ProcessJo
 	}, nil
 }
 
-func main() {
-	const (
-		port          = "8081"
-		workerCount   = 3
-		maxRetries    = 3
-		migrationsDir = "migrations"
-	)
+func (s *workerServer) ExecuteJob(ctx context.Context, req *proto.ExecuteJobRequest) (*proto.ExecuteJobResponse, error) {
+	if req.Job == nil {
+		return nil, status.Error(codes.InvalidArgument, "job is required")
+	}
 
+	jobReq, err := jobRequestFromProto(req.Job)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Stop waiting slightly before the caller's own deadline so it still receives the job ID
+	timeout := jobs.ClampWaitTimeout(time.Duration(req.TimeoutMs) * time.Millisecond)
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := max(time.Until(deadline)-200*time.Millisecond, 0); remaining < timeout {
+			timeout = remaining
+		}
+	}
+	// A job submitted now could not be reported back to the caller in time
+	if timeout == 0 {
+		return nil, status.Error(codes.DeadlineExceeded, "deadline too short to submit and wait for the job")
+	}
+
+	job, err := s.manager.Submit(jobReq)
+	if err != nil {
+		return nil, submitError(err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	finished, err := s.manager.WaitForJob(waitCtx, job.ID)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	if finished == nil {
+		finished = job
+	}
+
+	return &proto.ExecuteJobResponse{
+		JobId:  finished.ID,
+		Status: string(finished.Status),
+		Result: finished.Result,
+		Error:  finished.Error,
+		Done:   finished.Status.IsTerminal(),
+	}, nil
+}
+
+func main() {
 	logger.Init("worker-service")
+
+	cfg, err := config.Load("worker", os.Args[1:])
+	if errors.Is(err, config.ErrPrinted) || errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to load configuration")
+	}
+	if err := logger.SetLevel(cfg.Log.Level); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to set log level")
+	}
+
+	if len(cfg.Args) > 0 {
+		if cfg.Args[0] != "migrate" {
+			logger.Logger.Fatal().Str("command", cfg.Args[0]).Msg("Unknown command")
+		}
+		if err := db.RunMigrateCommand(cfg.DB(), cfg.Args[1:]); err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Migration failed")
+		}
+		return
+	}
+
 	logger.Logger.Info().Msg("Starting Worker Service with gRPC")
 
-	config := db.DefaultConfig()
-	database, err := db.Connect(config)
+	database, err := db.Connect(cfg.DB())
 	if err != nil {
 		logger.Logger.Fatal().Err(err).Msg("Failed to connect to database")
 	}
 	defer database.Close()
 
-	if err := db.RunMigrations(database, migrationsDir); err != nil {
-		logger.Logger.Fatal().Err(err).Msg("Failed to run migrations")
+	if cfg.AutoMigrate {
+		if err := db.RunMigrations(database); err != nil {
+			logger.Logger.Fatal().Err(err).Msg("Failed to run migrations")
+		}
 	}
 
 	store := db.NewStore(database)
-	manager := jobs.NewManager(store, maxRetries)
+	manager := jobs.NewManager(store, cfg.Jobs.DefaultMaxAttempts)
 
 	processor := &worker.DefaultJobProcessor{}
-	workerPool := worker.NewPool(manager, processor, workerCount)
-	workerPool.Start()
+	workerPools := worker.NewPoolSet(manager, processor)
+	workerPools.Apply(cfg.PoolConfigs(), cfg.Worker.PollInterval, cfg.AutoscalePolicy())
+
+	registry := worker.NewRegistry(manager, workerPools, Version, cfg.Worker.HeartbeatInterval, cfg.Worker.HeartbeatTimeout)
+	if err := registry.Start(); err != nil {
+		logger.Logger.Fatal().Err(err).Msg("Failed to register worker")
+	}
+
+	reloader := config.NewReloader("worker", os.Args[1:], cfg, func(next *config.Config) error {
+		workerPools.Apply(next.PoolConfigs(), next.Worker.PollInterval, next.AutoscalePolicy())
+		registry.SetHeartbeat(next.Worker.HeartbeatInterval, next.Worker.HeartbeatTimeout)
+		return nil
+	})
+	reloader.WatchSignals()
+
+	var adminServer *http.Server
+	if cfg.Worker.AdminPort != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		mux.Handle("/admin/config", reloader)
+		adminServer = &http.Server{Addr: ":" + cfg.Worker.AdminPort, Handler: mux}
+		go func() {
+			logger.Logger.Info().Str("port", cfg.Worker.AdminPort).Msg("Worker Service admin server listening")
+			if err := adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Logger.Fatal().Err(err).Msg("Failed to serve admin endpoints")
+			}
+		}()
+	}
 
 	var natsServer *nats.Server
-	useNATS := os.Getenv("USE_NATS")
-	if useNATS == "true" {
-		natsURL := os.Getenv("NATS_URL")
-		if natsURL == "" {
-			natsURL = "nats://localhost:4222"
-		}
+	if cfg.NATS.Enabled {
+		natsURL := cfg.NATS.URL
 		server, err := nats.NewServer(natsURL, manager)
 		if err != nil {
 			logger.Logger.Fatal().Err(err).Msg("Failed to create NATS server")
@@ -155,7 +598,7 @@ func main() {
 		logger.Logger.Info().Str("url", natsURL).Msg("NATS consumer started")
 	}
 
-	lis, err := net.Listen("tcp", ":"+port)
+	lis, err := net.Listen("tcp", ":"+cfg.Worker.Port)
 	if err != nil {
 		logger.Logger.Fatal().Err(err).Msg("Failed to listen")
 	}
@@ -164,7 +607,7 @@ func main() {
 	proto.RegisterWorkerServiceServer(s, &workerServer{manager: manager})
 
 	go func() {
-		logger.Logger.Info().Str("port", port).Msg("Worker Service gRPC server listening")
+		logger.Logger.Info().Str("port", cfg.Worker.Port).Msg("Worker Service gRPC server listening")
 		if err := s.Serve(lis); err != nil {
 			logger.Logger.Fatal().Err(err).Msg("Failed to serve")
 		}
@@ -174,11 +617,48 @@ func main() {
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	<-sigChan
 
-	logger.Logger.Info().Msg("Shutting down gracefully...")
-	s.GracefulStop()
-	workerPool.Stop()
+	// The shutdown timeout can be reloaded, so the running configuration's applies
+	running, _ := reloader.Current()
+	logger.Logger.Info().Dur("timeout", running.Worker.ShutdownTimeout).Msg("Shutting down gracefully...")
+	ctx, cancel := context.WithTimeout(context.Background(), running.Worker.ShutdownTimeout)
+	defer cancel()
+
+	// The pools stop claiming jobs right away while the servers finish their requests;
+	// jobs submitted in the meantime simply wait in the queue for the next worker
+	grpcStopped := make(chan struct{})
+	go func() {
+		stopGRPC(ctx, s)
+		close(grpcStopped)
+	}()
 	if natsServer != nil {
 		natsServer.Close()
 	}
+	if err := workerPools.Shutdown(ctx); err != nil {
+		logger.Logger.Warn().Err(err).Msg("Jobs still running at the shutdown deadline were released back to the queue")
+	}
+	<-grpcStopped
+	// Heartbeats go on while the pools drain so the process is not declared dead with jobs it is still finishing
+	registry.Stop()
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			logger.Logger.Warn().Err(err).Msg("Admin requests were still in flight at the shutdown deadline")
+		}
+	}
 	logger.Logger.Info().Msg("Worker Service stopped")
 }
+
+// stopGRPC stops the gRPC server gracefully, cutting off the RPCs still running when ctx is done,
+// such as clients following job logs
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.Stop()
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+type QueueControlRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type QueueStatesResponse struct {
+	States []*interfaces.QueueState `json:"states"`
+}
+
+// queueActions maps the last path segment of an admin queue route to the state it sets
+var queueActions = map[string]interfaces.QueueControlState{
+	"pause":  interfaces.QueuePaused,
+	"resume": interfaces.QueueActive,
+	"drain":  interfaces.QueueDraining,
+}
+
+func handleQueueStates(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		states, err := manager.GetQueueStates()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list queue states")
+			http.Error(w, "Failed to list queue states", http.StatusInternalServerError)
+			return
+		}
+		if states == nil {
+			states = []*interfaces.QueueState{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(QueueStatesResponse{States: states}); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
+
+// handleQueueControl serves POST {prefix}{name}/pause|resume|drain for queues or job types
+func handleQueueControl(manager *jobs.Manager, kind interfaces.QueueControlKind, prefix string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		idx := strings.LastIndex(path, "/")
+		if idx <= 0 {
+			http.Error(w, "Expected "+prefix+"{name}/{pause|resume|drain}", http.StatusNotFound)
+			return
+		}
+		name, action := path[:idx], path[idx+1:]
+
+		state, ok := queueActions[action]
+		if !ok {
+			http.Error(w, "Unknown action: "+action, http.StatusNotFound)
+			return
+		}
+
+		var req QueueControlRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			log.Error().Err(err).Msg("Invalid JSON request")
+			http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		queueState, err := manager.SetQueueState(kind, name, state, req.Reason)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, jobs.ErrInvalidQueueState) {
+				status = http.StatusBadRequest
+			}
+			log.Warn().Err(err).Msg("Failed to change queue state")
+			http.Error(w, "Failed to change queue state: "+err.Error(), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(queueState); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
+
+type TypeLimitRequest struct {
+	MaxConcurrency int `json:"max_concurrency"`
+}
+
+type TypeLimitsResponse struct {
+	Limits []*interfaces.TypeLimit `json:"limits"`
+}
+
+func handleTypeLimits(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		limits, err := manager.GetTypeLimits()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list type limits")
+			http.Error(w, "Failed to list type limits", http.StatusInternalServerError)
+			return
+		}
+		if limits == nil {
+			limits = []*interfaces.TypeLimit{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(TypeLimitsResponse{Limits: limits}); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
+
+// handleTypeLimit serves PUT and DELETE /admin/limits/{type}
+func handleTypeLimit(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobType := strings.TrimPrefix(r.URL.Path, "/admin/limits/")
+		if jobType == "" {
+			http.Error(w, "Job type is required", http.StatusBadRequest)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		var req TypeLimitRequest
+		switch r.Method {
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				log.Error().Err(err).Msg("Invalid JSON request")
+				http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			if req.MaxConcurrency <= 0 {
+				http.Error(w, "max_concurrency must be positive", http.StatusBadRequest)
+				return
+			}
+		case http.MethodDelete:
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		limit, err := manager.SetTypeLimit(jobType, req.MaxConcurrency)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, jobs.ErrInvalidTypeLimit) {
+				status = http.StatusBadRequest
+			}
+			log.Warn().Err(err).Msg("Failed to change type limit")
+			http.Error(w, "Failed to change type limit: "+err.Error(), status)
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(limit); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+type BatchRequest struct {
+	Jobs      []jobs.JobRequest         `json:"jobs"`
+	OnSuccess *interfaces.BatchCallback `json:"on_success,omitempty"`
+	OnFailure *interfaces.BatchCallback `json:"on_failure,omitempty"`
+}
+
+type BatchStatusResponse struct {
+	*interfaces.Batch
+	Status  string `json:"status"`
+	Pending int    `json:"pending"`
+}
+
+type CreateBatchResponse struct {
+	Batch   *BatchStatusResponse `json:"batch,omitempty"`
+	Results []jobs.SubmitResult  `json:"results"`
+	Error   string               `json:"error,omitempty"`
+}
+
+func newBatchStatusResponse(batch *interfaces.Batch) *BatchStatusResponse {
+	status := "running"
+	if batch.IsFinished() {
+		status = "succeeded"
+		if batch.Failed > 0 {
+			status = "failed"
+		}
+	}
+
+	return &BatchStatusResponse{
+		Batch:   batch,
+		Status:  status,
+		Pending: batch.Pending(),
+	}
+}
+
+func handleBatches(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		correlationID := getCorrelationID(r.Context())
+		log := logger.WithCorrelationID(correlationID)
+
+		var req BatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			log.Error().Err(err).Msg("Invalid JSON request")
+			http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		batch, results, err := manager.SubmitBatch(req.Jobs, req.OnSuccess, req.OnFailure)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, jobs.ErrInvalidBatch) {
+				status = http.StatusBadRequest
+			}
+			log.Warn().Err(err).Msg("Failed to submit batch")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(CreateBatchResponse{Results: results, Error: err.Error()})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(CreateBatchResponse{
+			Batch:   newBatchStatusResponse(batch),
+			Results: results,
+		}); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+			return
+		}
+
+		log.Info().Str("batch_id", batch.ID).Msg("Batch submitted successfully")
+	}
+}
+
+func handleBatchByID(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		batchID := strings.TrimPrefix(r.URL.Path, "/batches/")
+		if batchID == "" {
+			http.Error(w, "Batch ID is required", http.StatusBadRequest)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		batch, err := manager.GetBatch(batchID)
+		if err != nil {
+			log.Warn().Str("batch_id", batchID).Msg("Batch not found")
+			http.Error(w, "Batch not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(newBatchStatusResponse(batch)); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/mtr002/Job-Queue/internal/grpc"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+// maxNDJSONLineSize bounds a single line of an NDJSON batch body
+const maxNDJSONLineSize = 1 << 20
+
+type BulkSubmitResponse struct {
+	Results  []jobs.SubmitResult `json:"results"`
+	Accepted int                 `json:"accepted"`
+	Rejected int                 `json:"rejected"`
+}
+
+// batchEntry is one decoded item of a batch body, or the reason it could not be decoded
+type batchEntry struct {
+	req jobs.JobRequest
+	err string
+}
+
+func handleBatchJobs(manager *jobs.Manager, grpcClient *grpc.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		correlationID := getCorrelationID(r.Context())
+		log := logger.WithCorrelationID(correlationID)
+
+		entries, err := decodeBatch(r)
+		if err != nil {
+			log.Error().Err(err).Msg("Invalid batch request")
+			http.Error(w, "Invalid batch: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var reqs []jobs.JobRequest
+		var positions []int
+		results := make([]jobs.SubmitResult, len(entries))
+		for i, entry := range entries {
+			results[i].Index = i
+			if entry.err != "" {
+				results[i].Error = entry.err
+				continue
+			}
+			reqs = append(reqs, entry.req)
+			positions = append(positions, i)
+		}
+
+		var submitted []jobs.SubmitResult
+		if grpcClient != nil {
+			submitted, err = grpcClient.SubmitJobs(reqs)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to submit batch via gRPC")
+				http.Error(w, "Failed to submit jobs: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+		} else {
+			submitted = manager.SubmitJobs(reqs)
+		}
+
+		response := BulkSubmitResponse{Results: results}
+		for _, result := range submitted {
+			pos := positions[result.Index]
+			results[pos].ID = result.ID
+			results[pos].Error = result.Error
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				response.Rejected++
+			} else {
+				response.Accepted++
+			}
+		}
+
+		log.Info().
+			Int("accepted", response.Accepted).
+			Int("rejected", response.Rejected).
+			Msg("Batch submitted")
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
+
+// decodeBatch reads a JSON array of jobs, or one job per line for NDJSON bodies.
+// A malformed NDJSON line only fails that item; a malformed array fails the request.
+func decodeBatch(r *http.Request) ([]batchEntry, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return decodeNDJSON(r)
+	default:
+		return decodeJSONArray(r)
+	}
+}
+
+func decodeJSONArray(r *http.Request) ([]batchEntry, error) {
+	dec := json.NewDecoder(r.Body)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("expected a JSON array of jobs")
+	}
+
+	var entries []batchEntry
+	for dec.More() {
+		var req jobs.JobRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, fmt.Errorf("item %d: %w", len(entries), err)
+		}
+		entries = append(entries, batchEntry{req: req})
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func decodeNDJSON(r *http.Request) ([]batchEntry, error) {
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineSize)
+
+	var entries []batchEntry
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var req jobs.JobRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			entries = append(entries, batchEntry{err: "invalid JSON: " + err.Error()})
+			continue
+		}
+		entries = append(entries, batchEntry{req: req})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+type CompensationsResponse struct {
+	Jobs  []*interfaces.Job `json:"jobs"`
+	Count int               `json:"count"`
+}
+
+func handleCompensations(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		status := interfaces.CompensationStatus(r.URL.Query().Get("status"))
+		switch status {
+		case "", interfaces.CompensationPending, interfaces.CompensationCompleted, interfaces.CompensationFailed:
+		default:
+			http.Error(w, "Invalid compensation status: "+string(status), http.StatusBadRequest)
+			return
+		}
+
+		compensated, err := manager.GetCompensations(status)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list compensations")
+			http.Error(w, "Failed to list compensations", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(CompensationsResponse{
+			Jobs:  compensated,
+			Count: len(compensated),
+		}); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+type ReplayResponse struct {
+	Jobs  []*interfaces.Job `json:"jobs"`
+	Count int               `json:"count"`
+}
+
+// handleJobAction serves POST /jobs/{id}/cancel and POST /jobs/{id}/retry
+func handleJobAction(w http.ResponseWriter, r *http.Request, jobID, action string, manager *jobs.Manager) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+	if _, err := manager.GetJob(jobID); err != nil {
+		log.Warn().Str("job_id", jobID).Msg("Job not found")
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	}
+
+	var job *interfaces.Job
+	var err error
+	if action == "cancel" {
+		job, err = manager.CancelJob(jobID)
+	} else {
+		job, err = manager.RetryJob(jobID)
+	}
+	if errors.Is(err, jobs.ErrJobNotCancellable) || errors.Is(err, jobs.ErrJobNotRetryable) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Error().Str("job_id", jobID).Err(err).Msgf("Failed to %s job", action)
+		http.Error(w, "Failed to "+action+" job", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(job); err != nil {
+		log.Error().Err(err).Msg("Failed to encode response")
+	}
+}
+
+// handleReplayDeadLetters serves POST /dead-letters/replay, retrying the permanently failed jobs
+// matching the optional ?type= and ?queue= filters
+func handleReplayDeadLetters(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		replayed, err := manager.ReplayDeadLetters(r.URL.Query().Get("type"), r.URL.Query().Get("queue"))
+		if err != nil {
+			log.Error().Err(err).Int("replayed", len(replayed)).Msg("Failed to replay dead letters")
+			http.Error(w, "Failed to replay dead letters", http.StatusInternalServerError)
+			return
+		}
+		if replayed == nil {
+			replayed = []*interfaces.Job{}
+		}
+
+		log.Info().Int("replayed", len(replayed)).Msg("Dead letters replayed")
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(ReplayResponse{Jobs: replayed, Count: len(replayed)}); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
+
+// handleStats serves GET /stats, the number of jobs in each status overall and per queue
+func handleStats(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		stats, err := manager.Stats()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to get job stats")
+			http.Error(w, "Failed to get job stats", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(stats); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
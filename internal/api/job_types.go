@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+type JobTypeRequest struct {
+	Description        string                  `json:"description,omitempty"`
+	PayloadSchema      json.RawMessage         `json:"payload_schema,omitempty"`
+	DefaultQueue       string                  `json:"default_queue,omitempty"`
+	DefaultMaxAttempts int                     `json:"default_max_attempts,omitempty"`
+	RetryPolicy        *interfaces.RetryPolicy `json:"retry_policy,omitempty"`
+	TimeoutSeconds     int                     `json:"timeout_seconds,omitempty"`
+	Priority           int                     `json:"priority,omitempty"`
+	OwnerTeam          string                  `json:"owner_team,omitempty"`
+}
+
+type JobTypesResponse struct {
+	JobTypes []*interfaces.JobType `json:"job_types"`
+}
+
+func handleJobTypes(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		jobTypes, err := manager.GetJobTypes()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list job types")
+			http.Error(w, "Failed to list job types", http.StatusInternalServerError)
+			return
+		}
+		if jobTypes == nil {
+			jobTypes = []*interfaces.JobType{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(JobTypesResponse{JobTypes: jobTypes}); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
+
+// handleJobType serves GET, PUT and DELETE /job-types/{name}; PUT replaces the whole registration
+func handleJobType(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/job-types/")
+		if name == "" {
+			http.Error(w, "Job type name is required", http.StatusBadRequest)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		switch r.Method {
+		case http.MethodGet:
+			jobType, err := manager.GetJobType(name)
+			if err != nil {
+				log.Warn().Str("type", name).Msg("Job type not found")
+				http.Error(w, "Job type not found", http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(jobType); err != nil {
+				log.Error().Err(err).Msg("Failed to encode response")
+			}
+
+		case http.MethodPut:
+			var req JobTypeRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				log.Error().Err(err).Msg("Invalid JSON request")
+				http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			jobType, err := manager.SetJobType(interfaces.JobType{
+				Name:               name,
+				Description:        req.Description,
+				PayloadSchema:      req.PayloadSchema,
+				DefaultQueue:       req.DefaultQueue,
+				DefaultMaxAttempts: req.DefaultMaxAttempts,
+				RetryPolicy:        req.RetryPolicy,
+				TimeoutSeconds:     req.TimeoutSeconds,
+				Priority:           req.Priority,
+				OwnerTeam:          req.OwnerTeam,
+			})
+			if err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, jobs.ErrInvalidJobType) {
+					status = http.StatusBadRequest
+				}
+				log.Warn().Err(err).Msg("Failed to set job type")
+				http.Error(w, "Failed to set job type: "+err.Error(), status)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(jobType); err != nil {
+				log.Error().Err(err).Msg("Failed to encode response")
+			}
+
+		case http.MethodDelete:
+			if err := manager.DeleteJobType(name); err != nil {
+				log.Error().Err(err).Msg("Failed to delete job type")
+				http.Error(w, "Failed to delete job type", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
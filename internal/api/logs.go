@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+type JobLogsResponse struct {
+	Logs  []*interfaces.JobLog `json:"logs"`
+	Count int                  `json:"count"`
+}
+
+// handleJobLogs serves the log lines of a job after the optional ?after= line ID.
+// With ?follow=true the lines are streamed as newline-delimited JSON until the job finishes.
+func handleJobLogs(w http.ResponseWriter, r *http.Request, jobID string, manager *jobs.Manager) {
+	log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+	var afterID int64
+	if raw := r.URL.Query().Get("after"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id < 0 {
+			http.Error(w, "Invalid after: must be a log line ID", http.StatusBadRequest)
+			return
+		}
+		afterID = id
+	}
+
+	logs, err := manager.GetJobLogs(jobID, afterID)
+	if err != nil {
+		log.Warn().Str("job_id", jobID).Err(err).Msg("Failed to get job logs")
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	}
+
+	if r.URL.Query().Get("follow") != "true" {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(JobLogsResponse{
+			Logs:  logs,
+			Count: len(logs),
+		}); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+		return
+	}
+
+	// A followed job can run for longer than the server's write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	encoder := json.NewEncoder(w)
+	err = manager.FollowJobLogs(r.Context(), jobID, afterID, func(entry *interfaces.JobLog) error {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+		return rc.Flush()
+	})
+	if err != nil && r.Context().Err() == nil {
+		log.Error().Str("job_id", jobID).Err(err).Msg("Failed to follow job logs")
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+type RateLimitRequest struct {
+	Limit  int    `json:"limit"`
+	Period string `json:"period"`
+	Burst  int    `json:"burst,omitempty"`
+}
+
+type RateLimitsResponse struct {
+	RateLimits []*interfaces.RateLimit `json:"rate_limits"`
+}
+
+func handleRateLimits(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		limits, err := manager.GetRateLimits()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list rate limits")
+			http.Error(w, "Failed to list rate limits", http.StatusInternalServerError)
+			return
+		}
+		if limits == nil {
+			limits = []*interfaces.RateLimit{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(RateLimitsResponse{RateLimits: limits}); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
+
+// handleRateLimit serves PUT and DELETE /rate-limits/{queue|type}/{name}
+func handleRateLimit(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		kind, name, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/rate-limits/"), "/")
+		if !ok || name == "" {
+			http.Error(w, "Expected /rate-limits/{queue|type}/{name}", http.StatusNotFound)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		switch r.Method {
+		case http.MethodPut:
+			var req RateLimitRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				log.Error().Err(err).Msg("Invalid JSON request")
+				http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			limit, err := manager.SetRateLimit(interfaces.RateLimit{
+				Kind:   interfaces.QueueControlKind(kind),
+				Name:   name,
+				Limit:  req.Limit,
+				Period: req.Period,
+				Burst:  req.Burst,
+			})
+			if err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, jobs.ErrInvalidRateLimit) {
+					status = http.StatusBadRequest
+				}
+				log.Warn().Err(err).Msg("Failed to set rate limit")
+				http.Error(w, "Failed to set rate limit: "+err.Error(), status)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(limit); err != nil {
+				log.Error().Err(err).Msg("Failed to encode response")
+			}
+
+		case http.MethodDelete:
+			if err := manager.DeleteRateLimit(interfaces.QueueControlKind(kind), name); err != nil {
+				log.Error().Err(err).Msg("Failed to delete rate limit")
+				http.Error(w, "Failed to delete rate limit", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
@@ -3,7 +3,10 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,7 +30,25 @@ func AddRoutes(
 	hub *websocket.Hub,
 ) {
 	mux.HandleFunc("/jobs", correlationMiddleware(handleJobs(manager, grpcClient, natsClient, hub)))
+	mux.HandleFunc("/jobs/batch", correlationMiddleware(handleBatchJobs(manager, grpcClient)))
 	mux.HandleFunc("/jobs/", correlationMiddleware(handleJobByID(manager)))
+	mux.HandleFunc("/batches", correlationMiddleware(handleBatches(manager)))
+	mux.HandleFunc("/batches/", correlationMiddleware(handleBatchByID(manager)))
+	mux.HandleFunc("/workflows", correlationMiddleware(handleWorkflows(manager)))
+	mux.HandleFunc("/workflows/", correlationMiddleware(handleWorkflowByID(manager)))
+	mux.HandleFunc("/dead-letters/replay", correlationMiddleware(handleReplayDeadLetters(manager)))
+	mux.HandleFunc("/stats", correlationMiddleware(handleStats(manager)))
+	mux.HandleFunc("/compensations", correlationMiddleware(handleCompensations(manager)))
+	mux.HandleFunc("/admin/queues", correlationMiddleware(handleQueueStates(manager)))
+	mux.HandleFunc("/admin/queues/", correlationMiddleware(handleQueueControl(manager, interfaces.QueueControlQueue, "/admin/queues/")))
+	mux.HandleFunc("/admin/types/", correlationMiddleware(handleQueueControl(manager, interfaces.QueueControlType, "/admin/types/")))
+	mux.HandleFunc("/admin/limits", correlationMiddleware(handleTypeLimits(manager)))
+	mux.HandleFunc("/admin/limits/", correlationMiddleware(handleTypeLimit(manager)))
+	mux.HandleFunc("/rate-limits", correlationMiddleware(handleRateLimits(manager)))
+	mux.HandleFunc("/rate-limits/", correlationMiddleware(handleRateLimit(manager)))
+	mux.HandleFunc("/job-types", correlationMiddleware(handleJobTypes(manager)))
+	mux.HandleFunc("/job-types/", correlationMiddleware(handleJobType(manager)))
+	mux.HandleFunc("/workers", correlationMiddleware(handleWorkers(manager)))
 	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
 		websocket.HandleWebSocket(hub, w, r)
 	})
@@ -83,16 +104,30 @@ func handleJobs(manager *jobs.Manager, grpcClient *grpc.Client, natsClient *nats
 
 func handleJobByID(manager *jobs.Manager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
-
 		path := strings.TrimPrefix(r.URL.Path, "/jobs/")
 		if path == "" {
 			http.Error(w, "Job ID is required", http.StatusBadRequest)
 			return
 		}
+		if jobID, action, ok := strings.Cut(path, "/"); ok {
+			switch action {
+			case "logs":
+				if r.Method != http.MethodGet {
+					http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					return
+				}
+				handleJobLogs(w, r, jobID, manager)
+			case "cancel", "retry":
+				handleJobAction(w, r, jobID, action, manager)
+			default:
+				http.Error(w, "Unknown action: "+action, http.StatusNotFound)
+			}
+			return
+		}
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
 
 		correlationID := getCorrelationID(r.Context())
 		handleGetJob(w, r, path, manager, correlationID)
@@ -100,11 +135,6 @@ func handleJobByID(manager *jobs.Manager) http.HandlerFunc {
 }
 
 func handleCreateJob(w http.ResponseWriter, r *http.Request, manager *jobs.Manager, grpcClient *grpc.Client, natsClient *nats.Client, hub *websocket.Hub, correlationID string) {
-	type JobRequest struct {
-		Type    string `json:"type"`
-		Payload string `json:"payload"`
-	}
-
 	type JobResponse struct {
 		ID        string `json:"id"`
 		Type      string `json:"type"`
@@ -118,7 +148,7 @@ func handleCreateJob(w http.ResponseWriter, r *http.Request, manager *jobs.Manag
 
 	log := logger.WithCorrelationID(correlationID)
 
-	var req JobRequest
+	var req jobs.JobRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Error().Err(err).Msg("Invalid JSON request")
 		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
@@ -130,32 +160,35 @@ func handleCreateJob(w http.ResponseWriter, r *http.Request, manager *jobs.Manag
 		return
 	}
 
+	wait := r.URL.Query().Get("wait") == "true"
+	waitTimeout, err := parseWaitTimeout(r.URL.Query().Get("timeout"))
+	if err != nil {
+		log.Warn().Err(err).Msg("Invalid wait timeout")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	var job *interfaces.Job
-	var err error
 
 	if natsClient != nil {
-		msg := &nats.JobSubmissionMessage{
-			Type:        req.Type,
-			Payload:     req.Payload,
-			MaxAttempts: 3,
-		}
+		msg := nats.NewJobSubmissionMessage(req)
 		if err := natsClient.PublishJobSubmission(msg); err != nil {
 			log.Error().Err(err).Msg("Failed to submit job via NATS")
 			http.Error(w, "Failed to submit job: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
-		job, err = manager.SubmitJob(req.Type, req.Payload)
+		job, err = manager.Submit(req)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to create job in database")
-			http.Error(w, "Failed to submit job: "+err.Error(), http.StatusInternalServerError)
+			writeSubmitError(w, err)
 			return
 		}
 		log.Info().Str("job_id", job.ID).Msg("Job submitted via NATS")
 	} else if grpcClient != nil {
-		job, err = grpcClient.SubmitJob(req.Type, req.Payload, 3)
+		job, err = grpcClient.SubmitJob(req)
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to submit job via gRPC")
-			http.Error(w, "Failed to submit job: "+err.Error(), http.StatusInternalServerError)
+			writeSubmitError(w, err)
 			return
 		}
 		metrics.JobsSubmittedTotal.Inc()
@@ -164,8 +197,24 @@ func handleCreateJob(w http.ResponseWriter, r *http.Request, manager *jobs.Manag
 		return
 	}
 
+	websocket.BroadcastJobUpdate(hub, job)
+
+	status := http.StatusCreated
+	if wait {
+		job, status, err = waitForJob(w, r, manager, job, waitTimeout)
+		if err != nil {
+			log.Error().Err(err).Str("job_id", job.ID).Msg("Failed to wait for job")
+			w.Header().Set("Location", "/jobs/"+job.ID)
+			http.Error(w, "Job "+job.ID+" was submitted but waiting for it failed: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if status == http.StatusAccepted {
+			w.Header().Set("Location", "/jobs/"+job.ID)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(status)
 
 	response := JobResponse{
 		ID:        job.ID,
@@ -184,7 +233,85 @@ func handleCreateJob(w http.ResponseWriter, r *http.Request, manager *jobs.Manag
 	}
 
 	log.Info().Str("job_id", job.ID).Msg("Job submitted successfully")
-	websocket.BroadcastJobUpdate(hub, job)
+}
+
+// submitErrorStatus maps a submission error to its HTTP status code
+func submitErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, jobs.ErrQueueDraining):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, jobs.ErrDuplicateJob):
+		return http.StatusConflict
+	case errors.Is(err, jobs.ErrInvalidPayload), errors.Is(err, jobs.ErrInvalidJob):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+type PayloadErrorResponse struct {
+	Error  string            `json:"error"`
+	Fields []jobs.FieldError `json:"fields"`
+}
+
+// writeSubmitError reports a rejected submission; a payload that broke its schema is answered
+// with JSON listing the offending fields
+func writeSubmitError(w http.ResponseWriter, err error) {
+	var payloadErr *jobs.PayloadError
+	if !errors.As(err, &payloadErr) {
+		http.Error(w, "Failed to submit job: "+err.Error(), submitErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(PayloadErrorResponse{
+		Error:  payloadErr.Error(),
+		Fields: payloadErr.Fields,
+	})
+}
+
+// waitForJob blocks until the job finishes or timeout passes and returns the job to
+// report with the matching status code: 200 when finished, 202 when still running
+func waitForJob(w http.ResponseWriter, r *http.Request, manager *jobs.Manager, job *interfaces.Job, timeout time.Duration) (*interfaces.Job, int, error) {
+	// The server-wide write timeout is shorter than the longest allowed wait
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Now().Add(timeout + 5*time.Second)); err != nil {
+		return job, 0, fmt.Errorf("failed to extend write deadline: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	finished, err := manager.WaitForJob(ctx, job.ID)
+	switch {
+	case err == nil:
+		return finished, http.StatusOK, nil
+	case errors.Is(err, context.DeadlineExceeded):
+		if finished == nil {
+			finished = job
+		}
+		return finished, http.StatusAccepted, nil
+	default:
+		return job, 0, err
+	}
+}
+
+// parseWaitTimeout accepts a Go duration ("45s") or a number of seconds ("45")
+func parseWaitTimeout(raw string) (time.Duration, error) {
+	if raw == "" {
+		return jobs.DefaultWaitTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(raw)
+	if err != nil {
+		seconds, convErr := strconv.Atoi(raw)
+		if convErr != nil {
+			return 0, fmt.Errorf("invalid timeout %q", raw)
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	return jobs.ClampWaitTimeout(timeout), nil
 }
 
 func handleGetJob(w http.ResponseWriter, _ *http.Request, jobID string, manager *jobs.Manager, correlationID string) {
@@ -203,12 +330,28 @@ func handleGetJob(w http.ResponseWriter, _ *http.Request, jobID string, manager
 	}
 }
 
-func handleListJobs(w http.ResponseWriter, _ *http.Request, manager *jobs.Manager, correlationID string) {
+// handleListJobs serves the jobs matching the optional ?status=, ?type=, ?queue= and ?limit= filters
+func handleListJobs(w http.ResponseWriter, r *http.Request, manager *jobs.Manager, correlationID string) {
 	log := logger.WithCorrelationID(correlationID)
 
-	jobs, err := manager.GetAllJobs()
+	query := r.URL.Query()
+	filter := interfaces.JobFilter{
+		Status: interfaces.JobStatus(query.Get("status")),
+		Type:   query.Get("type"),
+		Queue:  query.Get("queue"),
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			http.Error(w, "Invalid limit: must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	jobs, err := manager.ListJobs(filter)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get all jobs")
+		log.Error().Err(err).Msg("Failed to list jobs")
 		http.Error(w, "Failed to retrieve jobs", http.StatusInternalServerError)
 		return
 	}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -15,38 +17,36 @@ import (
 
 func NewServer(manager *jobs.Manager, grpcClient *grpc.Client, natsClient *nats.Client, hub *websocket.Hub, port string, database *sql.DB) *Server {
 	SetDBConnection(database)
+
+	mux := http.NewServeMux()
+	AddRoutes(mux, manager, grpcClient, natsClient, hub)
+
 	return &Server{
-		manager:    manager,
-		grpcClient: grpcClient,
-		natsClient: natsClient,
-		hub:        hub,
-		port:       port,
+		server: &http.Server{
+			Addr:         fmt.Sprintf(":%s", port),
+			Handler:      mux,
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 15 * time.Second,
+			IdleTimeout:  60 * time.Second,
+		},
 	}
 }
 
 type Server struct {
-	manager    *jobs.Manager
-	grpcClient *grpc.Client
-	natsClient *nats.Client
-	hub        *websocket.Hub
-	port       string
+	server *http.Server
 }
 
+// Start serves the API until Shutdown is called
 func (s *Server) Start() {
-	addr := fmt.Sprintf(":%s", s.port)
-	logger.Logger.Info().Str("addr", addr).Msg("Starting server")
+	logger.Logger.Info().Str("addr", s.server.Addr).Msg("Starting server")
 
-	mux := http.NewServeMux()
-	AddRoutes(mux, s.manager, s.grpcClient, s.natsClient, s.hub)
-
-	server := &http.Server{
-		Addr:         addr,
-		Handler:      mux,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
-	if err := server.ListenAndServe(); err != nil {
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		logger.Logger.Fatal().Err(err).Msg("Failed to start server")
 	}
 }
+
+// Shutdown stops accepting connections and waits for in-flight requests to finish until ctx is done.
+// WebSocket connections are hijacked and not waited for.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+type WorkersResponse struct {
+	Workers []*interfaces.Worker `json:"workers"`
+}
+
+// handleWorkers serves GET /workers, listing the worker service processes in the worker registry
+func handleWorkers(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		workers, err := manager.ListWorkers()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list workers")
+			http.Error(w, "Failed to list workers", http.StatusInternalServerError)
+			return
+		}
+		if workers == nil {
+			workers = []*interfaces.Worker{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(WorkersResponse{Workers: workers}); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
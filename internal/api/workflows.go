@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+func handleWorkflows(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		var def jobs.WorkflowDefinition
+		if err := json.NewDecoder(r.Body).Decode(&def); err != nil {
+			log.Error().Err(err).Msg("Invalid JSON request")
+			http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		workflow, err := manager.SubmitWorkflow(def)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to submit workflow")
+			status := submitErrorStatus(err)
+			if errors.Is(err, jobs.ErrInvalidWorkflow) {
+				status = http.StatusBadRequest
+			}
+			http.Error(w, "Failed to submit workflow: "+err.Error(), status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(workflow); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+			return
+		}
+
+		log.Info().Str("workflow_id", workflow.ID).Msg("Workflow submitted successfully")
+	}
+}
+
+func handleWorkflowByID(manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		workflowID := strings.TrimPrefix(r.URL.Path, "/workflows/")
+		if workflowID == "" {
+			http.Error(w, "Workflow ID is required", http.StatusBadRequest)
+			return
+		}
+
+		log := logger.WithCorrelationID(getCorrelationID(r.Context()))
+
+		workflow, err := manager.GetWorkflow(workflowID)
+		if err != nil {
+			log.Warn().Str("workflow_id", workflowID).Msg("Workflow not found")
+			http.Error(w, "Workflow not found", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(workflow); err != nil {
+			log.Error().Err(err).Msg("Failed to encode response")
+		}
+	}
+}
@@ -0,0 +1,382 @@
+// Package config loads the settings of the API and worker services.
+// Each setting comes from, in increasing precedence, its default, a YAML file, the environment and a command-line flag.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"go.yaml.in/yaml/v2"
+
+	"github.com/mtr002/Job-Queue/internal/db"
+	"github.com/mtr002/Job-Queue/internal/worker"
+)
+
+// ErrPrinted is returned by Load after -print-config wrote the configuration, telling the caller to exit
+var ErrPrinted = errors.New("configuration printed")
+
+// Config holds the settings of both services; each service reads the sections it needs
+type Config struct {
+	Database DatabaseConfig `yaml:"database"`
+	Log      LogConfig      `yaml:"log"`
+	NATS     NATSConfig     `yaml:"nats"`
+	Jobs     JobsConfig     `yaml:"jobs"`
+	API      APIConfig      `yaml:"api"`
+	Worker   WorkerConfig   `yaml:"worker"`
+
+	// AutoMigrate makes the API and the worker service apply pending migrations on start; it defaults to true for both
+	AutoMigrate bool `yaml:"auto_migrate"`
+
+	// Args are the command-line arguments left after the flags, such as "migrate up"
+	Args []string `yaml:"-"`
+}
+
+// DatabaseConfig holds the PostgreSQL connection settings
+type DatabaseConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`
+	SSLMode  string `yaml:"sslmode"`
+}
+
+// LogConfig holds the logging settings
+type LogConfig struct {
+	Level string `yaml:"level"`
+}
+
+// NATSConfig holds the NATS settings
+type NATSConfig struct {
+	// Enabled makes the API service submit jobs over NATS instead of gRPC, and the worker service consume them
+	Enabled bool   `yaml:"enabled"`
+	URL     string `yaml:"url"`
+}
+
+// JobsConfig holds the defaults applied to submitted jobs
+type JobsConfig struct {
+	DefaultMaxAttempts int `yaml:"default_max_attempts"`
+}
+
+// APIConfig holds the settings of the API service
+type APIConfig struct {
+	Port string `yaml:"port"`
+	// WorkerAddr is the gRPC address of the worker service
+	WorkerAddr string `yaml:"worker_addr"`
+	// ShutdownTimeout is how long in-flight requests get to finish on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// WorkerConfig holds the settings of the worker service
+type WorkerConfig struct {
+	Port        string `yaml:"port"`
+	Concurrency int    `yaml:"concurrency"`
+	// MaxConcurrency makes the single pool autoscale between Concurrency and MaxConcurrency workers when it is above Concurrency
+	MaxConcurrency int `yaml:"max_concurrency"`
+	// Pools splits the workers into pools serving their own queues, such as "default,email:5;reports:1-8"; Concurrency is ignored when set
+	Pools string `yaml:"pools"`
+	// PollInterval is how often an idle worker looks for a job
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// AdminPort serves metrics and configuration reloads over HTTP; disabled if empty
+	AdminPort string `yaml:"admin_port"`
+	// ShutdownTimeout is how long in-flight jobs get to finish on shutdown before they are released back to the queue
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// HeartbeatInterval is how often the worker service reports to the worker registry
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+	// HeartbeatTimeout is how long without a heartbeat before the worker service is declared dead and its jobs released
+	HeartbeatTimeout time.Duration `yaml:"heartbeat_timeout"`
+	// Autoscale tunes how autoscaling pools follow their queues
+	Autoscale AutoscaleConfig `yaml:"autoscale"`
+}
+
+// AutoscaleConfig holds the settings of the worker pools that autoscale
+type AutoscaleConfig struct {
+	// Interval is how often the queues of an autoscaling pool are checked
+	Interval time.Duration `yaml:"interval"`
+	// MaxWait adds a worker while the oldest ready job has waited longer than this
+	MaxWait time.Duration `yaml:"max_wait"`
+	// ScaleDownDelay is how long a pool must need fewer workers than it runs before it shrinks
+	ScaleDownDelay time.Duration `yaml:"scale_down_delay"`
+}
+
+// Default returns the configuration used when nothing overrides it
+func Default() *Config {
+	return &Config{
+		Database: DatabaseConfig{
+			Host:     "localhost",
+			Port:     "5432",
+			User:     "postgres",
+			Password: "postgres",
+			Name:     "jobqueue",
+			SSLMode:  "disable",
+		},
+		Log:  LogConfig{Level: "info"},
+		NATS: NATSConfig{URL: "nats://localhost:4222"},
+		Jobs: JobsConfig{DefaultMaxAttempts: 3},
+		API:  APIConfig{Port: "8080", WorkerAddr: "localhost:8081", ShutdownTimeout: 10 * time.Second},
+		Worker: WorkerConfig{
+			Port:              "8081",
+			Concurrency:       3,
+			PollInterval:      time.Second,
+			AdminPort:         "9091",
+			ShutdownTimeout:   30 * time.Second,
+			HeartbeatInterval: 10 * time.Second,
+			HeartbeatTimeout:  30 * time.Second,
+			Autoscale: AutoscaleConfig{
+				Interval:       worker.DefaultAutoscalePolicy.Interval,
+				MaxWait:        worker.DefaultAutoscalePolicy.MaxWait,
+				ScaleDownDelay: worker.DefaultAutoscalePolicy.ScaleDownDelay,
+			},
+		},
+		AutoMigrate: true,
+	}
+}
+
+// setting ties a field of the configuration to its environment variable and flag
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	field func(c *Config) any
+}
+
+var settings = []setting{
+	{"DB_HOST", "db-host", "database host", func(c *Config) any { return &c.Database.Host }},
+	{"DB_PORT", "db-port", "database port", func(c *Config) any { return &c.Database.Port }},
+	{"DB_USER", "db-user", "database user", func(c *Config) any { return &c.Database.User }},
+	{"DB_PASSWORD", "db-password", "database password", func(c *Config) any { return &c.Database.Password }},
+	{"DB_NAME", "db-name", "database name", func(c *Config) any { return &c.Database.Name }},
+	{"DB_SSLMODE", "db-sslmode", "database SSL mode", func(c *Config) any { return &c.Database.SSLMode }},
+	{"LOG_LEVEL", "log-level", "log level: debug, info, warn or error", func(c *Config) any { return &c.Log.Level }},
+	{"USE_NATS", "nats", "submit and consume jobs over NATS instead of gRPC", func(c *Config) any { return &c.NATS.Enabled }},
+	{"NATS_URL", "nats-url", "NATS server URL", func(c *Config) any { return &c.NATS.URL }},
+	{"DEFAULT_MAX_ATTEMPTS", "max-attempts", "attempts of jobs whose request and type do not set them", func(c *Config) any { return &c.Jobs.DefaultMaxAttempts }},
+	{"API_PORT", "api-port", "HTTP port of the API service", func(c *Config) any { return &c.API.Port }},
+	{"WORKER_ADDR", "worker-addr", "gRPC address of the worker service, used by the API service", func(c *Config) any { return &c.API.WorkerAddr }},
+	{"API_SHUTDOWN_TIMEOUT", "api-shutdown-timeout", "how long in-flight requests get to finish on shutdown", func(c *Config) any { return &c.API.ShutdownTimeout }},
+	{"WORKER_PORT", "worker-port", "gRPC port of the worker service", func(c *Config) any { return &c.Worker.Port }},
+	{"WORKER_CONCURRENCY", "concurrency", "number of workers when no pools are configured", func(c *Config) any { return &c.Worker.Concurrency }},
+	{"WORKER_MAX_CONCURRENCY", "max-concurrency", "autoscale the workers up to this many when no pools are configured; fixed at concurrency if not above it", func(c *Config) any { return &c.Worker.MaxConcurrency }},
+	{"WORKER_POOLS", "pools", `worker pools, such as "default,email:5;reports:1-8" where a range of worker counts autoscales`, func(c *Config) any { return &c.Worker.Pools }},
+	{"WORKER_AUTOSCALE_INTERVAL", "autoscale-interval", "how often autoscaling pools check their queues", func(c *Config) any { return &c.Worker.Autoscale.Interval }},
+	{"WORKER_AUTOSCALE_MAX_WAIT", "autoscale-max-wait", "add a worker to an autoscaling pool while its oldest ready job has waited longer than this", func(c *Config) any { return &c.Worker.Autoscale.MaxWait }},
+	{"WORKER_AUTOSCALE_SCALE_DOWN_DELAY", "autoscale-scale-down-delay", "how long an autoscaling pool must need fewer workers before it shrinks", func(c *Config) any { return &c.Worker.Autoscale.ScaleDownDelay }},
+	{"WORKER_POLL_INTERVAL", "poll-interval", "how often an idle worker looks for a job, such as 500ms", func(c *Config) any { return &c.Worker.PollInterval }},
+	{"WORKER_ADMIN_PORT", "admin-port", "HTTP port of the worker service's metrics and admin endpoints; disabled if empty", func(c *Config) any { return &c.Worker.AdminPort }},
+	{"WORKER_SHUTDOWN_TIMEOUT", "shutdown-timeout", "how long in-flight jobs get to finish on shutdown before they are released back to the queue", func(c *Config) any { return &c.Worker.ShutdownTimeout }},
+	{"WORKER_HEARTBEAT_INTERVAL", "heartbeat-interval", "how often the worker service reports to the worker registry", func(c *Config) any { return &c.Worker.HeartbeatInterval }},
+	{"WORKER_HEARTBEAT_TIMEOUT", "heartbeat-timeout", "how long without a heartbeat before a worker service is declared dead and its jobs released", func(c *Config) any { return &c.Worker.HeartbeatTimeout }},
+	{"AUTO_MIGRATE", "auto-migrate", "apply pending database migrations on start", func(c *Config) any { return &c.AutoMigrate }},
+}
+
+// Load builds the configuration of a service from args, the command line without the program name.
+// The YAML file is named by -config or CONFIG_FILE; with -print-config the result is written to stdout and ErrPrinted returned.
+// An environment variable that is set applies even when empty, so WORKER_ADMIN_PORT= disables the admin server.
+func Load(name string, args []string) (*Config, error) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML configuration file")
+	printConfig := flags.Bool("print-config", false, "print the effective configuration and exit")
+
+	// Flags are only recorded while parsing and applied last, after the file and the environment
+	type flagValue struct {
+		setting setting
+		value   string
+	}
+	var flagValues []flagValue
+	for _, s := range settings {
+		record := func(value string) error {
+			flagValues = append(flagValues, flagValue{s, value})
+			return nil
+		}
+		if _, ok := s.field(&Config{}).(*bool); ok {
+			flags.BoolFunc(s.flag, s.usage+" (env "+s.env+")", record)
+		} else {
+			flags.Func(s.flag, s.usage+" (env "+s.env+")", record)
+		}
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env); ok {
+			if err := set(s.field(cfg), value); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", s.env, err)
+			}
+		}
+	}
+	for _, fv := range flagValues {
+		if err := set(fv.setting.field(cfg), fv.value); err != nil {
+			return nil, fmt.Errorf("invalid -%s: %w", fv.setting.flag, err)
+		}
+	}
+	cfg.Args = flags.Args()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	if *printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			return nil, err
+		}
+		return nil, ErrPrinted
+	}
+	return cfg, nil
+}
+
+// loadFile reads a YAML file over the configuration, rejecting keys it does not know
+func (c *Config) loadFile(path string) error {
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+	default:
+		return fmt.Errorf("unsupported config file %s: must be .yaml or .yml", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// set parses value into the field it points to
+func set(field any, value string) error {
+	switch f := field.(type) {
+	case *string:
+		*f = value
+	case *int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		*f = n
+	case *time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration", value)
+		}
+		*f = d
+	case *bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		*f = b
+	default:
+		return fmt.Errorf("unsupported setting type %T", field)
+	}
+	return nil
+}
+
+// Validate checks that every setting holds a usable value
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Database.Host != "", "database.host is required")
+	check(validPort(c.Database.Port), "database.port must be a port number, got %q", c.Database.Port)
+	check(c.Database.Name != "", "database.name is required")
+	check(c.Database.SSLMode != "", "database.sslmode is required")
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		check(false, "log.level must be debug, info, warn or error, got %q", c.Log.Level)
+	}
+	check(!c.NATS.Enabled || c.NATS.URL != "", "nats.url is required when nats is enabled")
+	check(c.Jobs.DefaultMaxAttempts > 0, "jobs.default_max_attempts must be positive, got %d", c.Jobs.DefaultMaxAttempts)
+	check(validPort(c.API.Port), "api.port must be a port number, got %q", c.API.Port)
+	check(c.API.WorkerAddr != "", "api.worker_addr is required")
+	check(c.API.ShutdownTimeout > 0, "api.shutdown_timeout must be positive, got %v", c.API.ShutdownTimeout)
+	check(validPort(c.Worker.Port), "worker.port must be a port number, got %q", c.Worker.Port)
+	check(c.Worker.Concurrency > 0, "worker.concurrency must be positive, got %d", c.Worker.Concurrency)
+	check(c.Worker.MaxConcurrency >= 0, "worker.max_concurrency must not be negative, got %d", c.Worker.MaxConcurrency)
+	check(c.Worker.Autoscale.Interval > 0, "worker.autoscale.interval must be positive, got %v", c.Worker.Autoscale.Interval)
+	check(c.Worker.Autoscale.MaxWait > 0, "worker.autoscale.max_wait must be positive, got %v", c.Worker.Autoscale.MaxWait)
+	check(c.Worker.Autoscale.ScaleDownDelay >= 0, "worker.autoscale.scale_down_delay must not be negative, got %v", c.Worker.Autoscale.ScaleDownDelay)
+	check(c.Worker.PollInterval > 0, "worker.poll_interval must be positive, got %v", c.Worker.PollInterval)
+	check(c.Worker.ShutdownTimeout > 0, "worker.shutdown_timeout must be positive, got %v", c.Worker.ShutdownTimeout)
+	check(c.Worker.HeartbeatInterval > 0, "worker.heartbeat_interval must be positive, got %v", c.Worker.HeartbeatInterval)
+	check(c.Worker.HeartbeatTimeout > c.Worker.HeartbeatInterval, "worker.heartbeat_timeout must be longer than worker.heartbeat_interval, got %v", c.Worker.HeartbeatTimeout)
+	check(c.Worker.AdminPort == "" || validPort(c.Worker.AdminPort), "worker.admin_port must be a port number, got %q", c.Worker.AdminPort)
+	if c.Worker.Pools != "" {
+		if _, err := worker.ParsePoolConfigs(c.Worker.Pools); err != nil {
+			check(false, "worker.pools: %v", err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+// Print writes the configuration as YAML with the database password masked
+func (c *Config) Print(w io.Writer) error {
+	masked := *c
+	if masked.Database.Password != "" {
+		masked.Database.Password = "********"
+	}
+
+	data, err := yaml.Marshal(&masked)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// PoolConfigs returns the worker pools to start: the configured pools, or a single pool serving every queue
+func (c *Config) PoolConfigs() []worker.PoolConfig {
+	if c.Worker.Pools == "" {
+		return []worker.PoolConfig{{WorkerCount: c.Worker.Concurrency, MaxWorkers: c.Worker.MaxConcurrency}}
+	}
+	// Validate already parsed the pools successfully
+	pools, _ := worker.ParsePoolConfigs(c.Worker.Pools)
+	return pools
+}
+
+// AutoscalePolicy returns how autoscaling worker pools follow their queues
+func (c *Config) AutoscalePolicy() worker.AutoscalePolicy {
+	return worker.AutoscalePolicy{
+		Interval:       c.Worker.Autoscale.Interval,
+		MaxWait:        c.Worker.Autoscale.MaxWait,
+		ScaleDownDelay: c.Worker.Autoscale.ScaleDownDelay,
+	}
+}
+
+// DB returns the settings for connecting to the database
+func (c *Config) DB() *db.Config {
+	return &db.Config{
+		Host:     c.Database.Host,
+		Port:     c.Database.Port,
+		User:     c.Database.User,
+		Password: c.Database.Password,
+		DBName:   c.Database.Name,
+		SSLMode:  c.Database.SSLMode,
+	}
+}
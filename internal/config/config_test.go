@@ -0,0 +1,170 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv unsets every variable Load reads, so the environment of the test run does not leak in
+func clearEnv(t *testing.T) {
+	t.Helper()
+	// Setenv restores the variable once the test is done; it is unset since an empty variable applies too
+	unset := func(name string) {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+	unset("CONFIG_FILE")
+	for _, s := range settings {
+		unset(s.env)
+	}
+}
+
+// writeConfigFile writes a YAML configuration file for the test and returns its path
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load("test", nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if len(cfg.Args) != 0 {
+		t.Errorf("Args = %v, want none", cfg.Args)
+	}
+	cfg.Args = nil
+	if want := Default(); !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load() = %+v, want the defaults %+v", cfg, want)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	clearEnv(t)
+	path := writeConfigFile(t, "config.yaml", `
+database:
+  host: file-host
+  port: "6000"
+log:
+  level: warn
+worker:
+  concurrency: 7
+  poll_interval: 2s
+auto_migrate: false
+`)
+	t.Setenv("DB_PORT", "6001")
+	t.Setenv("LOG_LEVEL", "error")
+	t.Setenv("WORKER_POLL_INTERVAL", "3s")
+	t.Setenv("WORKER_ADMIN_PORT", "")
+
+	cfg, err := Load("test", []string{"-config", path, "-log-level", "debug", "-auto-migrate", "migrate", "up"})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"default when nothing sets it", cfg.Database.User, Default().Database.User},
+		{"file over default", cfg.Database.Host, "file-host"},
+		{"file over default for numbers", cfg.Worker.Concurrency, 7},
+		{"env over file", cfg.Database.Port, "6001"},
+		{"env over file for durations", cfg.Worker.PollInterval, 3 * time.Second},
+		{"empty env over default", cfg.Worker.AdminPort, ""},
+		{"flag over env and file", cfg.Log.Level, "debug"},
+		{"boolean flag without value over file", cfg.AutoMigrate, true},
+		{"arguments after the flags", cfg.Args, []string{"migrate", "up"}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigFileFromEnv(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, "config.yml", "nats:\n  enabled: true\n"))
+
+	cfg, err := Load("test", nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.NATS.Enabled {
+		t.Errorf("NATS.Enabled = false, want true from the file named by CONFIG_FILE")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		env    map[string]string
+		args   []string
+		errMsg string
+	}{
+		{
+			name:   "unknown file key",
+			file:   "databse:\n  host: x\n",
+			errMsg: "invalid config file",
+		},
+		{
+			name:   "invalid env value",
+			env:    map[string]string{"WORKER_CONCURRENCY": "many"},
+			errMsg: `invalid WORKER_CONCURRENCY: "many" is not an integer`,
+		},
+		{
+			name:   "invalid flag value",
+			args:   []string{"-shutdown-timeout", "soon"},
+			errMsg: `invalid -shutdown-timeout: "soon" is not a duration`,
+		},
+		{
+			name:   "invalid result",
+			args:   []string{"-log-level", "loud"},
+			errMsg: "log.level",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", writeConfigFile(t, "config.yaml", tt.file)}, args...)
+			}
+
+			_, err := Load("test", args)
+			if err == nil {
+				t.Fatalf("Load succeeded, want error containing %q", tt.errMsg)
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Load error = %q, want it to contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestLoadUnsupportedFile(t *testing.T) {
+	clearEnv(t)
+
+	_, err := Load("test", []string{"-config", writeConfigFile(t, "config.json", "{}")})
+	if err == nil || !strings.Contains(err.Error(), "must be .yaml or .yml") {
+		t.Errorf("Load error = %v, want an unsupported config file error", err)
+	}
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/mtr002/Job-Queue/internal/logger"
+	"github.com/mtr002/Job-Queue/internal/metrics"
+)
+
+// Reloader re-reads the configuration of a running service and applies the settings that can change without a restart:
+// the log level, and whatever the service's apply function takes care of
+type Reloader struct {
+	name  string
+	args  []string
+	apply func(cfg *Config) error
+
+	mu      sync.Mutex
+	current *Config
+	version int
+}
+
+// NewReloader starts tracking cfg, loaded by Load(name, args), as version 1.
+// apply is called with every reloaded configuration; if it fails the previous one stays in place.
+func NewReloader(name string, args []string, cfg *Config, apply func(cfg *Config) error) *Reloader {
+	metrics.ConfigVersion.Set(1)
+	return &Reloader{name: name, args: args, apply: apply, current: cfg, version: 1}
+}
+
+// Current returns the running configuration and its version
+func (r *Reloader) Current() (*Config, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current, r.version
+}
+
+// Reload loads the configuration again from the same file, environment and flags and applies it.
+// Settings that need a restart keep their running values, with a warning if they changed.
+func (r *Reloader) Reload() (*Config, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := Load(r.name, r.args)
+	if err == nil {
+		if changed := next.keepRestartSettings(r.current); len(changed) > 0 {
+			logger.Logger.Warn().Strs("settings", changed).Msg("Configuration changes that need a restart were not applied")
+		}
+		err = r.apply(next)
+	}
+	if err == nil {
+		// Validated by Load, so only the apply function can fail
+		err = logger.SetLevel(next.Log.Level)
+	}
+	if err != nil {
+		metrics.ConfigReloadsTotal.WithLabelValues("failed").Inc()
+		return nil, r.version, err
+	}
+
+	r.current = next
+	r.version++
+	metrics.ConfigVersion.Set(float64(r.version))
+	metrics.ConfigReloadsTotal.WithLabelValues("applied").Inc()
+	logger.Logger.Info().Int("version", r.version).Msg("Configuration reloaded")
+
+	return next, r.version, nil
+}
+
+// keepRestartSettings copies the settings only read on start from the running configuration,
+// returning the names of those the reloaded configuration tried to change
+func (c *Config) keepRestartSettings(running *Config) []string {
+	var changed []string
+	keep := func(name string, differs bool) {
+		if differs {
+			changed = append(changed, name)
+		}
+	}
+
+	keep("database", c.Database != running.Database)
+	keep("nats", c.NATS != running.NATS)
+	keep("jobs", c.Jobs != running.Jobs)
+	keep("api", c.API != running.API)
+	keep("worker.port", c.Worker.Port != running.Worker.Port)
+	keep("worker.admin_port", c.Worker.AdminPort != running.Worker.AdminPort)
+	keep("auto_migrate", c.AutoMigrate != running.AutoMigrate)
+
+	c.Database = running.Database
+	c.NATS = running.NATS
+	c.Jobs = running.Jobs
+	c.API = running.API
+	c.Worker.Port = running.Worker.Port
+	c.Worker.AdminPort = running.Worker.AdminPort
+	c.AutoMigrate = running.AutoMigrate
+	c.Args = running.Args
+
+	return changed
+}
+
+// WatchSignals reloads the configuration whenever the process receives SIGHUP
+func (r *Reloader) WatchSignals() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	go func() {
+		for range signals {
+			logger.Logger.Info().Msg("Received SIGHUP, reloading configuration")
+			if _, _, err := r.Reload(); err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to reload configuration")
+			}
+		}
+	}()
+}
+
+// ServeHTTP shows the running configuration as YAML on GET and reloads it on POST
+func (r *Reloader) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		cfg, version := r.Current()
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Header().Set("X-Config-Version", strconv.Itoa(version))
+		if err := cfg.Print(w); err != nil {
+			logger.Logger.Error().Err(err).Msg("Failed to encode configuration")
+		}
+
+	case http.MethodPost:
+		_, version, err := r.Reload()
+		if err != nil {
+			logger.Logger.Error().Err(err).Msg("Failed to reload configuration")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]int{"version": version}); err != nil {
+			logger.Logger.Error().Err(err).Msg("Failed to encode response")
+		}
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,216 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+const batchColumns = `id, total, succeeded, failed, on_success_type, on_success_payload, on_success_job_id,
+	on_failure_type, on_failure_payload, on_failure_job_id, callback_job_id, created_at, completed_at`
+
+// scanBatch reads a single batch selected with batchColumns
+func scanBatch(row rowScanner) (*interfaces.Batch, error) {
+	batch := &interfaces.Batch{}
+	var onSuccessType, onSuccessPayload, onSuccessJobID, onFailureType, onFailurePayload, onFailureJobID sql.NullString
+	var callbackJobID sql.NullString
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&batch.ID, &batch.Total, &batch.Succeeded, &batch.Failed,
+		&onSuccessType, &onSuccessPayload, &onSuccessJobID, &onFailureType, &onFailurePayload, &onFailureJobID,
+		&callbackJobID, &batch.CreatedAt, &completedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if onSuccessType.Valid {
+		batch.OnSuccess = &interfaces.BatchCallback{
+			Type: onSuccessType.String, Payload: onSuccessPayload.String, JobID: onSuccessJobID.String,
+		}
+	}
+	if onFailureType.Valid {
+		batch.OnFailure = &interfaces.BatchCallback{
+			Type: onFailureType.String, Payload: onFailurePayload.String, JobID: onFailureJobID.String,
+		}
+	}
+	batch.CallbackJobID = callbackJobID.String
+	if completedAt.Valid {
+		batch.CompletedAt = &completedAt.Time
+	}
+
+	return batch, nil
+}
+
+// callbackColumns splits an optional callback into nullable type, payload and job ID values
+func callbackColumns(cb *interfaces.BatchCallback) (sql.NullString, sql.NullString, sql.NullString) {
+	if cb == nil {
+		return sql.NullString{}, sql.NullString{}, sql.NullString{}
+	}
+	return nullString(cb.Type), sql.NullString{String: cb.Payload, Valid: true}, nullString(cb.JobID)
+}
+
+// CreateBatch inserts a batch, all of its member jobs and its blocked callback jobs in one transaction
+func (s *Store) CreateBatch(batch *interfaces.Batch, jobs, callbacks []*interfaces.Job) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	onSuccessType, onSuccessPayload, onSuccessJobID := callbackColumns(batch.OnSuccess)
+	onFailureType, onFailurePayload, onFailureJobID := callbackColumns(batch.OnFailure)
+
+	query := `
+		INSERT INTO batches (id, total, on_success_type, on_success_payload, on_success_job_id,
+			on_failure_type, on_failure_payload, on_failure_job_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = tx.Exec(query, batch.ID, batch.Total, onSuccessType, onSuccessPayload, onSuccessJobID,
+		onFailureType, onFailurePayload, onFailureJobID, batch.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	if err := copyJobs(tx, jobs); err != nil {
+		return err
+	}
+	for _, callback := range callbacks {
+		if err := insertJob(tx, callback); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetBatch retrieves a batch by ID
+func (s *Store) GetBatch(id string) (*interfaces.Batch, error) {
+	query := `SELECT ` + batchColumns + ` FROM batches WHERE id = $1`
+
+	batch, err := scanBatch(s.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("batch with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+// recordBatchOutcomes counts the batch members among the given jobs that reached a terminal status
+// as succeeded or failed, in the transaction that stored their status. It returns the batches they finished.
+func recordBatchOutcomes(tx *sql.Tx, jobs ...*interfaces.Job) ([]*interfaces.Batch, error) {
+	var finished []*interfaces.Batch
+	for _, job := range jobs {
+		if job.BatchID == "" || !job.Status.IsTerminal() {
+			continue
+		}
+
+		batch, done, err := recordBatchOutcome(tx, job.BatchID, job.Status == interfaces.StatusCompleted)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			finished = append(finished, batch)
+		}
+	}
+
+	return finished, nil
+}
+
+// recordBatchOutcome counts one member job as succeeded or failed.
+// The returned bool is true only for the call that finished the batch.
+func recordBatchOutcome(tx *sql.Tx, batchID string, succeeded bool) (*interfaces.Batch, bool, error) {
+	query := `SELECT ` + batchColumns + ` FROM batches WHERE id = $1 FOR UPDATE`
+	batch, err := scanBatch(tx.QueryRow(query, batchID))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to lock batch: %w", err)
+	}
+
+	wasFinished := batch.CompletedAt != nil
+	if succeeded {
+		batch.Succeeded++
+	} else {
+		batch.Failed++
+	}
+
+	updateQuery := `
+		UPDATE batches
+		SET succeeded = $2, failed = $3,
+			completed_at = CASE WHEN $4 AND completed_at IS NULL THEN NOW() ELSE completed_at END
+		WHERE id = $1
+		RETURNING completed_at
+	`
+	var completedAt sql.NullTime
+	err = tx.QueryRow(updateQuery, batch.ID, batch.Succeeded, batch.Failed, batch.IsFinished()).Scan(&completedAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to update batch: %w", err)
+	}
+	if completedAt.Valid {
+		batch.CompletedAt = &completedAt.Time
+	}
+
+	finished := !wasFinished && batch.CompletedAt != nil
+	if finished {
+		if err := startBatchCallback(tx, batch); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return batch, finished, nil
+}
+
+// startBatchCallback releases the blocked callback job matching the outcome of a finished batch and drops the other.
+// A callback job cancelled while it waited stays cancelled.
+func startBatchCallback(tx *sql.Tx, batch *interfaces.Batch) error {
+	cb := batch.Callback()
+	for _, other := range []*interfaces.BatchCallback{batch.OnSuccess, batch.OnFailure} {
+		if other == nil || other == cb || other.JobID == "" {
+			continue
+		}
+		if _, err := tx.Exec(`DELETE FROM jobs WHERE id = $1 AND status = 'blocked'`, other.JobID); err != nil {
+			return fmt.Errorf("failed to drop unused batch callback job: %w", err)
+		}
+	}
+
+	// Batches stored before their callback jobs were have theirs enqueued by the job manager
+	if cb == nil || cb.JobID == "" {
+		return nil
+	}
+
+	payload := cb.Payload
+	if payload == "" {
+		payload = batch.Summary()
+	}
+
+	query := `UPDATE jobs SET status = 'pending', payload = $2, updated_at = NOW() WHERE id = $1 AND status = 'blocked'`
+	if _, err := tx.Exec(query, cb.JobID, payloadJSON(payload)); err != nil {
+		return fmt.Errorf("failed to start batch callback job: %w", err)
+	}
+
+	query = `UPDATE batches SET callback_job_id = $2 WHERE id = $1`
+	if _, err := tx.Exec(query, batch.ID, cb.JobID); err != nil {
+		return fmt.Errorf("failed to set batch callback job: %w", err)
+	}
+	batch.CallbackJobID = cb.JobID
+
+	return nil
+}
+
+// SetBatchCallbackJob records the callback job enqueued for a finished batch
+func (s *Store) SetBatchCallbackJob(batchID, jobID string) error {
+	query := `UPDATE batches SET callback_job_id = $2 WHERE id = $1`
+
+	if _, err := s.db.Exec(query, batchID, jobID); err != nil {
+		return fmt.Errorf("failed to set batch callback job: %w", err)
+	}
+
+	return nil
+}
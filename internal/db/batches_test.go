@@ -0,0 +1,80 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+func TestFinishedBatchStartsMatchingCallback(t *testing.T) {
+	store := openTestStore(t)
+
+	onSuccess := newTestJob("notify_success", "default")
+	onSuccess.Status = interfaces.StatusBlocked
+	onFailure := newTestJob("notify_failure", "default")
+	onFailure.Status = interfaces.StatusBlocked
+	batch := &interfaces.Batch{
+		ID:        uuid.New().String(),
+		Total:     2,
+		OnSuccess: &interfaces.BatchCallback{Type: onSuccess.Type, Payload: `{"ok": true}`, JobID: onSuccess.ID},
+		OnFailure: &interfaces.BatchCallback{Type: onFailure.Type, JobID: onFailure.ID},
+		CreatedAt: time.Now(),
+	}
+
+	var members []*interfaces.Job
+	for i := 0; i < 2; i++ {
+		member := newTestJob("echo", "default")
+		member.BatchID = batch.ID
+		member.CreatedAt = time.Now()
+		member.UpdatedAt = member.CreatedAt
+		members = append(members, member)
+	}
+	for _, callback := range []*interfaces.Job{onSuccess, onFailure} {
+		callback.CreatedAt = time.Now()
+		callback.UpdatedAt = callback.CreatedAt
+	}
+	if err := store.CreateBatch(batch, members, []*interfaces.Job{onSuccess, onFailure}); err != nil {
+		t.Fatalf("CreateBatch failed: %v", err)
+	}
+
+	members[0].Status = interfaces.StatusCompleted
+	if _, finished, err := store.UpdateJobAndResolveDependents(members[0]); err != nil || len(finished) != 0 {
+		t.Fatalf("UpdateJobAndResolveDependents() = %v, %v, want the batch still running", finished, err)
+	}
+	members[1].Status = interfaces.StatusPermanentFailed
+	_, finished, err := store.UpdateJobAndResolveDependents(members[1])
+	if err != nil {
+		t.Fatalf("UpdateJobAndResolveDependents failed: %v", err)
+	}
+	if len(finished) != 1 || finished[0].CallbackJobID != onFailure.ID {
+		t.Fatalf("finished batches %+v, want the batch with its failure callback", finished)
+	}
+
+	callback, err := store.GetJob(onFailure.ID)
+	if err != nil {
+		t.Fatalf("GetJob failed: %v", err)
+	}
+	var summary map[string]any
+	if err := json.Unmarshal([]byte(callback.Payload), &summary); err != nil {
+		t.Fatalf("callback payload %q is not JSON: %v", callback.Payload, err)
+	}
+	if callback.Status != interfaces.StatusPending || summary["succeeded"] != 1.0 || summary["failed"] != 1.0 {
+		t.Errorf("failure callback is %s with payload %s, want pending with the batch summary", callback.Status, callback.Payload)
+	}
+
+	if _, err := store.GetJob(onSuccess.ID); err == nil {
+		t.Errorf("success callback job still exists, want it dropped")
+	}
+
+	stored, err := store.GetBatch(batch.ID)
+	if err != nil {
+		t.Fatalf("GetBatch failed: %v", err)
+	}
+	if stored.CallbackJobID != onFailure.ID || stored.OnSuccess.JobID != onSuccess.ID {
+		t.Errorf("batch = %+v, want its callback job recorded", stored)
+	}
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// compensableQuery selects the completed ancestors of a failed job that declare a compensation type
+// and have not been rolled back yet, most recently completed first, which is the order to undo them in
+const compensableQuery = `
+	WITH RECURSIVE ancestors (id) AS (
+		SELECT depends_on_id FROM job_dependencies WHERE job_id = $1
+		UNION
+		SELECT d.depends_on_id FROM job_dependencies d JOIN ancestors a ON d.job_id = a.id
+	)
+	SELECT ` + jobColumns + `
+	FROM jobs
+	WHERE id IN (SELECT id FROM ancestors)
+		AND status = 'completed'
+		AND compensation_type IS NOT NULL
+		AND compensation_status IS NULL
+	ORDER BY updated_at DESC
+`
+
+// GetCompensable lists the completed ancestors of a failed job that still need rolling back
+func (s *Store) GetCompensable(failedJobID string) ([]*interfaces.Job, error) {
+	rows, err := s.db.Query(compensableQuery, failedJobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query compensable jobs: %w", err)
+	}
+
+	return scanJobs(rows)
+}
+
+// ClaimCompensations stores the rollback jobs for the ancestors of a failed job in one transaction
+// with marking those ancestors pending, so a rollback is never recorded without its job.
+// Each job in compensations rolls back its CompensatesJobID; ancestors another failure claimed
+// meanwhile are skipped and ancestors without a job are marked failed. The stored jobs are
+// chained in the order to undo them in, each depending on the one before, and returned.
+func (s *Store) ClaimCompensations(failedJobID string, compensations []*interfaces.Job) ([]*interfaces.Job, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(compensableQuery+" FOR UPDATE", failedJobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query compensable jobs: %w", err)
+	}
+	originals, err := scanJobs(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	byOriginal := make(map[string]*interfaces.Job, len(compensations))
+	for _, job := range compensations {
+		byOriginal[job.CompensatesJobID] = job
+	}
+
+	var created []*interfaces.Job
+	for _, original := range originals {
+		job, ok := byOriginal[original.ID]
+		if !ok {
+			if _, err := tx.Exec(`UPDATE jobs SET compensation_status = $2 WHERE id = $1`,
+				original.ID, interfaces.CompensationFailed); err != nil {
+				return nil, fmt.Errorf("failed to mark compensation failed: %w", err)
+			}
+			continue
+		}
+
+		if len(created) > 0 {
+			job.DependsOn = []string{created[len(created)-1].ID}
+			if err := resolveNewJobDependencies(tx, job); err != nil {
+				return nil, err
+			}
+		}
+		if err := insertJob(tx, job); err != nil {
+			return nil, err
+		}
+		if len(job.DependsOn) > 0 {
+			if err := insertDependencies(tx, job); err != nil {
+				return nil, err
+			}
+		}
+
+		query := `UPDATE jobs SET compensation_status = $2, compensation_job_id = $3 WHERE id = $1`
+		if _, err := tx.Exec(query, original.ID, interfaces.CompensationPending, job.ID); err != nil {
+			return nil, fmt.Errorf("failed to mark compensation pending: %w", err)
+		}
+		created = append(created, job)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return created, nil
+}
+
+// SetCompensationStatus records how the rollback of the given job went
+func (s *Store) SetCompensationStatus(jobID string, status interfaces.CompensationStatus) error {
+	query := `UPDATE jobs SET compensation_status = $2 WHERE id = $1`
+
+	if _, err := s.db.Exec(query, jobID, status); err != nil {
+		return fmt.Errorf("failed to set compensation status: %w", err)
+	}
+
+	return nil
+}
+
+// GetCompensations lists jobs whose rollback was triggered, optionally filtered by its status
+func (s *Store) GetCompensations(status interfaces.CompensationStatus) ([]*interfaces.Job, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE compensation_status IS NOT NULL AND ($1 = '' OR compensation_status = $1)
+		ORDER BY updated_at DESC
+	`
+
+	rows, err := s.db.Query(query, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query compensations: %w", err)
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
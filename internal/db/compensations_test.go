@@ -0,0 +1,70 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+func TestClaimCompensationsEnqueuesRollbacks(t *testing.T) {
+	store := openTestStore(t)
+
+	var originals []*interfaces.Job
+	for _, compensation := range []string{"undo_reserve", "undo_charge", "undo_ship"} {
+		original := newTestJob("step", "default")
+		original.Status = interfaces.StatusCompleted
+		original.CompensationType = compensation
+		originals = append(originals, original)
+	}
+	failed := newTestJob("step", "default")
+	failed.DependsOn = []string{originals[0].ID, originals[1].ID, originals[2].ID}
+	createTestJobs(t, store, append(originals, failed)...)
+
+	compensable, err := store.GetCompensable(failed.ID)
+	if err != nil {
+		t.Fatalf("GetCompensable failed: %v", err)
+	}
+	if len(compensable) != 3 || compensable[0].ID != originals[2].ID {
+		t.Fatalf("GetCompensable() = %d jobs, want all three newest first", len(compensable))
+	}
+
+	// The rollback of the middle step could not be built
+	var compensations []*interfaces.Job
+	for _, original := range []*interfaces.Job{originals[2], originals[0]} {
+		job := newTestJob(original.CompensationType, "default")
+		job.DependencyPolicy = interfaces.DependencyPolicyRunAnyway
+		job.CompensatesJobID = original.ID
+		compensations = append(compensations, job)
+	}
+	created, err := store.ClaimCompensations(failed.ID, compensations)
+	if err != nil {
+		t.Fatalf("ClaimCompensations failed: %v", err)
+	}
+	if len(created) != 2 || created[0].Status != interfaces.StatusPending || created[1].Status != interfaces.StatusBlocked {
+		t.Fatalf("ClaimCompensations() = %+v, want the newest rollback pending and the next blocked on it", created)
+	}
+
+	want := map[string]struct {
+		status interfaces.CompensationStatus
+		jobID  string
+	}{
+		originals[0].ID: {interfaces.CompensationPending, created[1].ID},
+		originals[1].ID: {interfaces.CompensationFailed, ""},
+		originals[2].ID: {interfaces.CompensationPending, created[0].ID},
+	}
+	for id, w := range want {
+		original, err := store.GetJob(id)
+		if err != nil {
+			t.Fatalf("GetJob failed: %v", err)
+		}
+		if original.CompensationStatus != w.status || original.CompensationJobID != w.jobID {
+			t.Errorf("job %s compensation is %q by %q, want %q by %q", id, original.CompensationStatus, original.CompensationJobID, w.status, w.jobID)
+		}
+	}
+
+	// A second failure finds the rollbacks already claimed
+	again, err := store.ClaimCompensations(failed.ID, compensations)
+	if err != nil || len(again) != 0 {
+		t.Errorf("ClaimCompensations() again = %+v, %v, want nothing", again, err)
+	}
+}
@@ -4,10 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"os"
 
 	_ "github.com/lib/pq"
-	"github.com/pressly/goose/v3"
 )
 
 // Config holds database configuration
@@ -20,18 +18,6 @@ type Config struct {
 	SSLMode  string
 }
 
-// DefaultConfig returns a default database configuration
-func DefaultConfig() *Config {
-	return &Config{
-		Host:     getEnv("DB_HOST", "localhost"),
-		Port:     getEnv("DB_PORT", "5432"),
-		User:     getEnv("DB_USER", "postgres"),
-		Password: getEnv("DB_PASSWORD", "postgres"),
-		DBName:   getEnv("DB_NAME", "jobqueue"),
-		SSLMode:  getEnv("DB_SSLMODE", "disable"),
-	}
-}
-
 // Connect establishes a connection to the PostgreSQL database
 func Connect(config *Config) (*sql.DB, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
@@ -49,25 +35,3 @@ func Connect(config *Config) (*sql.DB, error) {
 	log.Printf("Connected to PostgreSQL database: %s", config.DBName)
 	return db, nil
 }
-
-// RunMigrations runs the database migrations using goose
-func RunMigrations(db *sql.DB, migrationsDir string) error {
-	if err := goose.SetDialect("postgres"); err != nil {
-		return fmt.Errorf("failed to set goose dialect: %w", err)
-	}
-
-	if err := goose.Up(db, migrationsDir); err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
-	}
-
-	log.Println("Database migrations completed successfully")
-	return nil
-}
-
-// getEnv gets environment variable with fallback
-func getEnv(key, fallback string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return fallback
-}
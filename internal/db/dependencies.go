@@ -0,0 +1,196 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// dependencyPolicy returns the job's policy, defaulting to fail
+func dependencyPolicy(job *interfaces.Job) interfaces.DependencyPolicy {
+	if job.DependencyPolicy == "" {
+		return interfaces.DependencyPolicyFail
+	}
+	return job.DependencyPolicy
+}
+
+// resolveNewJobDependencies sets the initial status of a job from the jobs it depends on.
+// The parents are share-locked so they cannot finish unnoticed before the edges are committed.
+func resolveNewJobDependencies(tx *sql.Tx, job *interfaces.Job) error {
+	query := `SELECT id, status FROM jobs WHERE id = ANY($1) FOR SHARE`
+
+	rows, err := tx.Query(query, pq.Array(job.DependsOn))
+	if err != nil {
+		return fmt.Errorf("failed to query dependencies: %w", err)
+	}
+	parents, err := scanStatuses(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range job.DependsOn {
+		if _, ok := parents[id]; !ok {
+			return fmt.Errorf("%w: %s", interfaces.ErrDependencyNotFound, id)
+		}
+	}
+
+	status, errMsg := interfaces.ResolveDependencyStatus(dependencyPolicy(job), parents)
+	job.Status = status
+	job.Error = errMsg
+	return nil
+}
+
+// insertDependencies stores one edge per job the given job depends on
+func insertDependencies(tx *sql.Tx, job *interfaces.Job) error {
+	query := `
+		INSERT INTO job_dependencies (job_id, depends_on_id)
+		SELECT $1, unnest($2::varchar[])
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := tx.Exec(query, job.ID, pq.Array(job.DependsOn)); err != nil {
+		return fmt.Errorf("failed to create job dependencies: %w", err)
+	}
+
+	return nil
+}
+
+// getDependencies lists the IDs of the jobs the given job depends on
+func (s *Store) getDependencies(jobID string) ([]string, error) {
+	rows, err := s.db.Query(`SELECT depends_on_id FROM job_dependencies WHERE job_id = $1`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return ids, nil
+}
+
+// UpdateJobAndResolveDependents stores a finished job and, in the same transaction,
+// unblocks or cascades to every blocked job depending on it and counts them in their batches.
+// It returns the dependents whose status changed and the batches that finished.
+func (s *Store) UpdateJobAndResolveDependents(job *interfaces.Job) ([]*interfaces.Job, []*interfaces.Batch, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateJob(tx, job); err != nil {
+		return nil, nil, err
+	}
+
+	resolved, err := resolveDependents(tx, job.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	finished, err := recordBatchOutcomes(tx, append([]*interfaces.Job{job}, resolved...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return resolved, finished, nil
+}
+
+// resolveDependents re-evaluates the blocked dependents of parentID.
+// Dependents that fail or are skipped cascade to their own dependents.
+func resolveDependents(tx *sql.Tx, parentID string) ([]*interfaces.Job, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE status = 'blocked'
+			AND id IN (SELECT job_id FROM job_dependencies WHERE depends_on_id = $1)
+		ORDER BY created_at ASC
+		FOR UPDATE
+	`
+
+	rows, err := tx.Query(query, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependents: %w", err)
+	}
+	dependents, err := scanJobs(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var resolved []*interfaces.Job
+	for _, dep := range dependents {
+		rows, err := tx.Query(`
+			SELECT p.id, p.status
+			FROM job_dependencies d JOIN jobs p ON p.id = d.depends_on_id
+			WHERE d.job_id = $1
+		`, dep.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query dependencies: %w", err)
+		}
+		parents, err := scanStatuses(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		status, errMsg := interfaces.ResolveDependencyStatus(dependencyPolicy(dep), parents)
+		if status == interfaces.StatusBlocked {
+			continue
+		}
+
+		dep.Status = status
+		dep.Error = errMsg
+		if err := updateJob(tx, dep); err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, dep)
+
+		if status.IsTerminal() {
+			cascaded, err := resolveDependents(tx, dep.ID)
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, cascaded...)
+		}
+	}
+
+	return resolved, nil
+}
+
+// scanStatuses reads id/status pairs and closes rows
+func scanStatuses(rows *sql.Rows) (map[string]interfaces.JobStatus, error) {
+	defer rows.Close()
+
+	statuses := make(map[string]interfaces.JobStatus)
+	for rows.Next() {
+		var id string
+		var status interfaces.JobStatus
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, fmt.Errorf("failed to scan job status: %w", err)
+		}
+		statuses[id] = status
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// ListJobs retrieves the jobs matching the filter, newest first
+func (s *Store) ListJobs(filter interfaces.JobFilter) ([]*interfaces.Job, error) {
+	var conditions []string
+	var args []any
+	add := func(column string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if filter.Status != "" {
+		add("status", filter.Status)
+	}
+	if filter.Type != "" {
+		add("type", filter.Type)
+	}
+	if filter.Queue != "" {
+		add("queue", filter.Queue)
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+// CountJobs returns how many jobs each queue holds in each status
+func (s *Store) CountJobs() ([]*interfaces.JobCount, error) {
+	rows, err := s.db.Query(`SELECT queue, status, COUNT(*) FROM jobs GROUP BY queue, status ORDER BY queue, status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*interfaces.JobCount
+	for rows.Next() {
+		count := &interfaces.JobCount{}
+		if err := rows.Scan(&count.Queue, &count.Status, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan job count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate job counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetQueueDepth counts the jobs ready to run in the given queues, or in every queue if none are given.
+// Only jobs a worker could claim right now are counted, and a type with a concurrency limit counts
+// no more jobs than it has free slots.
+func (s *Store) GetQueueDepth(queues []string) (*interfaces.QueueDepth, error) {
+	query := `
+		WITH ready AS (
+			SELECT type, COUNT(*) AS jobs, MIN(COALESCE(retry_after, created_at)) AS oldest
+			FROM jobs
+			WHERE ` + runnableJob + `
+				AND (COALESCE(cardinality($1::text[]), 0) = 0 OR queue = ANY($1))
+			GROUP BY type
+		)
+		SELECT COALESCE(SUM(CASE
+				WHEN l.type IS NULL THEN r.jobs
+				ELSE LEAST(r.jobs, l.max_concurrency - (SELECT COUNT(*) FROM jobs p WHERE p.type = l.type AND p.status = 'processing'))
+			END), 0), MIN(r.oldest)
+		FROM ready r
+		LEFT JOIN job_type_limits l ON l.type = r.type
+	`
+
+	depth := &interfaces.QueueDepth{}
+	var oldest sql.NullTime
+	if err := s.db.QueryRow(query, pq.Array(queues)).Scan(&depth.Ready, &oldest); err != nil {
+		return nil, fmt.Errorf("failed to get queue depth: %w", err)
+	}
+	if oldest.Valid {
+		depth.OldestReadyAt = &oldest.Time
+	}
+
+	return depth, nil
+}
+
+// CancelJob cancels a job that has not started running and resolves the blocked jobs depending on it,
+// counting them in their batches in the same transaction. It returns a nil job if the job is running or already finished.
+func (s *Store) CancelJob(id string) (*interfaces.Job, []*interfaces.Job, []*interfaces.Batch, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE jobs
+		SET status = 'cancelled', error = 'job was cancelled', retry_after = NULL, updated_at = NOW()
+		WHERE id = $1 AND status IN ('pending', 'blocked', 'retrying')
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(tx.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+
+	resolved, err := resolveDependents(tx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	finished, err := recordBatchOutcomes(tx, append([]*interfaces.Job{job}, resolved...)...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return job, resolved, finished, nil
+}
+
+// RetryJob moves a job that failed, expired or was cancelled back to pending with a fresh set of attempts.
+// It starts over rather than resuming, so its progress and checkpoint are cleared along with its outcome.
+// Jobs in a batch or workflow are left alone since their outcome was already counted,
+// and a nil job is returned for them as for jobs in any other status.
+func (s *Store) RetryJob(id string) (*interfaces.Job, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE id = $1 AND status IN ('permanent_failed', 'expired', 'cancelled')
+			AND batch_id IS NULL AND workflow_id IS NULL
+		FOR UPDATE
+	`
+
+	job, err := scanJob(tx.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	// A time window key is held by the job itself, so only the status based scopes are checked again
+	if job.UniqueKey != "" && job.UniqueScope != interfaces.UniqueWindow {
+		if err := claimUniqueKey(tx, job); err != nil {
+			return nil, err
+		}
+	}
+
+	query = `
+		UPDATE jobs
+		SET status = 'pending', attempts = 0, result = '', error = '', retry_after = NULL, expires_at = NULL,
+			progress = 0, progress_message = NULL, checkpoint = NULL, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + jobColumns
+
+	job, err = scanJob(tx.QueryRow(query, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry job: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return job, nil
+}
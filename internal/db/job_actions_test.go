@@ -0,0 +1,97 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+func TestGetQueueDepthCountsOnlyClaimableJobs(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, store *Store) []*interfaces.Job
+		want  int
+	}{
+		{
+			name: "ordering key counts its head only",
+			setup: func(t *testing.T, store *Store) []*interfaces.Job {
+				var jobs []*interfaces.Job
+				for i := 0; i < 3; i++ {
+					job := newTestJob("echo", "default")
+					job.OrderingKey = "account-1"
+					jobs = append(jobs, job)
+				}
+				return jobs
+			},
+			want: 1,
+		},
+		{
+			name: "paused queue",
+			setup: func(t *testing.T, store *Store) []*interfaces.Job {
+				err := store.SetQueueState(&interfaces.QueueState{
+					Kind: interfaces.QueueControlQueue, Name: "default", State: interfaces.QueuePaused, UpdatedAt: time.Now(),
+				})
+				if err != nil {
+					t.Fatalf("SetQueueState failed: %v", err)
+				}
+				return []*interfaces.Job{newTestJob("echo", "default")}
+			},
+			want: 0,
+		},
+		{
+			name: "type limit caps the count at its free slots",
+			setup: func(t *testing.T, store *Store) []*interfaces.Job {
+				setTestTypeLimit(t, store, "limited", 2)
+				running := newTestJob("limited", "default")
+				running.Status = interfaces.StatusProcessing
+				return []*interfaces.Job{running, newTestJob("limited", "default"), newTestJob("limited", "default")}
+			},
+			want: 1,
+		},
+		{
+			name: "type at its limit",
+			setup: func(t *testing.T, store *Store) []*interfaces.Job {
+				setTestTypeLimit(t, store, "limited", 1)
+				running := newTestJob("limited", "default")
+				running.Status = interfaces.StatusProcessing
+				return []*interfaces.Job{running, newTestJob("limited", "default")}
+			},
+			want: 0,
+		},
+		{
+			name: "empty rate limit bucket",
+			setup: func(t *testing.T, store *Store) []*interfaces.Job {
+				setTestRateLimit(t, store, interfaces.QueueControlQueue, "default", 1, "hour", 0)
+				return []*interfaces.Job{newTestJob("echo", "default")}
+			},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := openTestStore(t)
+
+			free := newTestJob("echo", "other")
+			createTestJobs(t, store, append(tt.setup(t, store), free)...)
+
+			depth, err := store.GetQueueDepth(nil)
+			if err != nil {
+				t.Fatalf("GetQueueDepth failed: %v", err)
+			}
+			if depth.Ready != tt.want+1 {
+				t.Errorf("GetQueueDepth().Ready = %d, want %d", depth.Ready, tt.want+1)
+			}
+		})
+	}
+}
+
+// setTestTypeLimit stores the concurrency limit of a job type
+func setTestTypeLimit(t *testing.T, store *Store, jobType string, maxConcurrency int) {
+	t.Helper()
+	err := store.SetTypeLimit(&interfaces.TypeLimit{Type: jobType, MaxConcurrency: maxConcurrency, UpdatedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("SetTypeLimit failed: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// AddJobLog stores a line logged while processing a job and fills in its ID
+func (s *Store) AddJobLog(entry *interfaces.JobLog) error {
+	query := `
+		INSERT INTO job_logs (job_id, attempt, level, message, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	err := s.db.QueryRow(query, entry.JobID, entry.Attempt, entry.Level, entry.Message, entry.CreatedAt).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to add job log: %w", err)
+	}
+
+	return nil
+}
+
+// GetJobLogs retrieves up to limit log lines of a job that come after the line with afterID, oldest first
+func (s *Store) GetJobLogs(jobID string, afterID int64, limit int) ([]*interfaces.JobLog, error) {
+	query := `
+		SELECT id, job_id, attempt, level, message, created_at
+		FROM job_logs
+		WHERE job_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := s.db.Query(query, jobID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job logs: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []*interfaces.JobLog
+	for rows.Next() {
+		entry := &interfaces.JobLog{}
+		if err := rows.Scan(&entry.ID, &entry.JobID, &entry.Attempt, &entry.Level, &entry.Message, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan job log: %w", err)
+		}
+		logs = append(logs, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return logs, nil
+}
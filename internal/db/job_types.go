@@ -0,0 +1,130 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+const jobTypeColumns = `name, description, payload_schema, default_queue, default_max_attempts,
+	retry_base_delay_seconds, retry_max_delay_seconds, timeout_seconds, priority, owner_team, created_at, updated_at`
+
+// scanJobType reads a single job type selected with jobTypeColumns
+func scanJobType(row rowScanner) (*interfaces.JobType, error) {
+	jobType := &interfaces.JobType{}
+	var payloadSchema []byte
+	var description, defaultQueue, ownerTeam sql.NullString
+	var defaultMaxAttempts, retryBaseDelay, retryMaxDelay, timeout sql.NullInt64
+
+	err := row.Scan(&jobType.Name, &description, &payloadSchema, &defaultQueue, &defaultMaxAttempts,
+		&retryBaseDelay, &retryMaxDelay, &timeout, &jobType.Priority, &ownerTeam, &jobType.CreatedAt, &jobType.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	jobType.Description = description.String
+	jobType.PayloadSchema = payloadSchema
+	jobType.DefaultQueue = defaultQueue.String
+	jobType.DefaultMaxAttempts = int(defaultMaxAttempts.Int64)
+	if retryBaseDelay.Valid || retryMaxDelay.Valid {
+		jobType.RetryPolicy = &interfaces.RetryPolicy{
+			BaseDelaySeconds: int(retryBaseDelay.Int64),
+			MaxDelaySeconds:  int(retryMaxDelay.Int64),
+		}
+	}
+	jobType.TimeoutSeconds = int(timeout.Int64)
+	jobType.OwnerTeam = ownerTeam.String
+
+	return jobType, nil
+}
+
+// nullInt stores zero as NULL
+func nullInt(n int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(n), Valid: n != 0}
+}
+
+// SetJobType creates or replaces a job type, keeping the creation time of an existing one
+func (s *Store) SetJobType(jobType *interfaces.JobType) error {
+	query := `
+		INSERT INTO job_types (name, description, payload_schema, default_queue, default_max_attempts,
+			retry_base_delay_seconds, retry_max_delay_seconds, timeout_seconds, priority, owner_team, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+		ON CONFLICT (name) DO UPDATE
+		SET description = EXCLUDED.description, payload_schema = EXCLUDED.payload_schema,
+			default_queue = EXCLUDED.default_queue, default_max_attempts = EXCLUDED.default_max_attempts,
+			retry_base_delay_seconds = EXCLUDED.retry_base_delay_seconds, retry_max_delay_seconds = EXCLUDED.retry_max_delay_seconds,
+			timeout_seconds = EXCLUDED.timeout_seconds, priority = EXCLUDED.priority, owner_team = EXCLUDED.owner_team,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at
+	`
+
+	var payloadSchema any
+	if len(jobType.PayloadSchema) > 0 {
+		payloadSchema = []byte(jobType.PayloadSchema)
+	}
+
+	var retryBaseDelay, retryMaxDelay sql.NullInt64
+	if jobType.RetryPolicy != nil {
+		retryBaseDelay = sql.NullInt64{Int64: int64(jobType.RetryPolicy.BaseDelaySeconds), Valid: true}
+		retryMaxDelay = nullInt(jobType.RetryPolicy.MaxDelaySeconds)
+	}
+
+	err := s.db.QueryRow(query, jobType.Name, nullString(jobType.Description), payloadSchema,
+		nullString(jobType.DefaultQueue), nullInt(jobType.DefaultMaxAttempts), retryBaseDelay, retryMaxDelay,
+		nullInt(jobType.TimeoutSeconds), jobType.Priority, nullString(jobType.OwnerTeam), jobType.UpdatedAt).Scan(&jobType.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set job type: %w", err)
+	}
+
+	return nil
+}
+
+// GetJobType retrieves a job type by name
+func (s *Store) GetJobType(name string) (*interfaces.JobType, error) {
+	query := `SELECT ` + jobTypeColumns + ` FROM job_types WHERE name = $1`
+
+	jobType, err := scanJobType(s.db.QueryRow(query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("job type %s not found", name)
+		}
+		return nil, fmt.Errorf("failed to get job type: %w", err)
+	}
+
+	return jobType, nil
+}
+
+// GetJobTypes retrieves every registered job type
+func (s *Store) GetJobTypes() ([]*interfaces.JobType, error) {
+	query := `SELECT ` + jobTypeColumns + ` FROM job_types ORDER BY name`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job types: %w", err)
+	}
+	defer rows.Close()
+
+	var jobTypes []*interfaces.JobType
+	for rows.Next() {
+		jobType, err := scanJobType(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job type: %w", err)
+		}
+		jobTypes = append(jobTypes, jobType)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return jobTypes, nil
+}
+
+// DeleteJobType removes a job type; jobs of that type are kept
+func (s *Store) DeleteJobType(name string) error {
+	if _, err := s.db.Exec(`DELETE FROM job_types WHERE name = $1`, name); err != nil {
+		return fmt.Errorf("failed to delete job type: %w", err)
+	}
+	return nil
+}
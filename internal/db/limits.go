@@ -0,0 +1,90 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// SetTypeLimit stores the concurrency limit of a job type; a zero limit removes it
+func (s *Store) SetTypeLimit(limit *interfaces.TypeLimit) error {
+	if limit.MaxConcurrency == 0 {
+		query := `DELETE FROM job_type_limits WHERE type = $1`
+		if _, err := s.db.Exec(query, limit.Type); err != nil {
+			return fmt.Errorf("failed to remove type limit: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		INSERT INTO job_type_limits (type, max_concurrency, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (type) DO UPDATE
+		SET max_concurrency = EXCLUDED.max_concurrency, updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := s.db.Exec(query, limit.Type, limit.MaxConcurrency, limit.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to set type limit: %w", err)
+	}
+
+	return nil
+}
+
+// GetTypeLimits retrieves every job type concurrency limit together with the number of running jobs
+func (s *Store) GetTypeLimits() ([]*interfaces.TypeLimit, error) {
+	query := `
+		SELECT l.type, l.max_concurrency,
+			(SELECT COUNT(*) FROM jobs WHERE jobs.type = l.type AND jobs.status = 'processing'),
+			l.updated_at
+		FROM job_type_limits l
+		ORDER BY l.type
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query type limits: %w", err)
+	}
+	defer rows.Close()
+
+	var limits []*interfaces.TypeLimit
+	for rows.Next() {
+		limit := &interfaces.TypeLimit{}
+		if err := rows.Scan(&limit.Type, &limit.MaxConcurrency, &limit.Running, &limit.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan type limit: %w", err)
+		}
+		limits = append(limits, limit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return limits, nil
+}
+
+// acquireTypeSlot reports whether a job of the given type may start without exceeding its limit.
+// Claims of a limited type are serialized on an advisory lock held until the claim commits, so the
+// count below always includes jobs claimed by other workers and replicas.
+func acquireTypeSlot(tx *sql.Tx, jobType string) (bool, error) {
+	var maxConcurrency int
+	err := tx.QueryRow(`SELECT max_concurrency FROM job_type_limits WHERE type = $1`, jobType).Scan(&maxConcurrency)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get type limit: %w", err)
+	}
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('job_type_limits'), hashtext($1))`, jobType); err != nil {
+		return false, fmt.Errorf("failed to lock type limit: %w", err)
+	}
+
+	var running int
+	query := `SELECT COUNT(*) FROM jobs WHERE type = $1 AND status = 'processing'`
+	if err := tx.QueryRow(query, jobType).Scan(&running); err != nil {
+		return false, fmt.Errorf("failed to count running jobs: %w", err)
+	}
+
+	return running < maxConcurrency, nil
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+
+	"github.com/mtr002/Job-Queue/migrations"
+)
+
+// MigrateCommands are the goose commands Migrate accepts; up-to and down-to take a target version
+var MigrateCommands = []string{"up", "up-to", "down", "down-to", "redo", "status", "version"}
+
+// migrationLockID keys the advisory lock that makes services migrating at the same time take turns
+const migrationLockID = 7340931
+
+// Migrate runs a goose command against the migrations embedded in the binary.
+// Concurrent callers wait on an advisory lock, so the second one finds nothing left to do instead of racing the first.
+func Migrate(db *sql.DB, command string, args ...string) error {
+	if !slices.Contains(MigrateCommands, command) {
+		return fmt.Errorf("unknown migrate command %q", command)
+	}
+
+	goose.SetBaseFS(migrations.FS)
+	if err := goose.SetDialect("postgres"); err != nil {
+		return fmt.Errorf("failed to set goose dialect: %w", err)
+	}
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to lock migrations: %w", err)
+	}
+	defer conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationLockID)
+
+	if err := goose.RunContext(ctx, command, db, ".", args...); err != nil {
+		return fmt.Errorf("failed to run migrate %s: %w", command, err)
+	}
+	return nil
+}
+
+// RunMigrations applies every pending migration
+func RunMigrations(db *sql.DB) error {
+	if err := Migrate(db, "up"); err != nil {
+		return err
+	}
+
+	log.Println("Database migrations completed successfully")
+	return nil
+}
+
+// RunMigrateCommand serves the "migrate <command> [version]" subcommand of the services,
+// connecting with config to run the goose command named by args
+func RunMigrateCommand(config *Config, args []string) error {
+	if len(args) == 0 || !slices.Contains(MigrateCommands, args[0]) {
+		return fmt.Errorf("usage: migrate {%s} [version]", strings.Join(MigrateCommands, "|"))
+	}
+
+	database, err := Connect(config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer database.Close()
+
+	return Migrate(database, args[0], args[1:]...)
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+func TestOrderingKeysStayFIFOUnderConcurrentWorkers(t *testing.T) {
+	store := openTestStore(t)
+
+	const (
+		keys       = 4
+		jobsPerKey = 25
+		workers    = 16
+	)
+
+	var jobs []*interfaces.Job
+	for i := 0; i < jobsPerKey; i++ {
+		for k := 0; k < keys; k++ {
+			job := newTestJob("echo", "default")
+			job.OrderingKey = fmt.Sprintf("key-%d", k)
+			jobs = append(jobs, job)
+		}
+	}
+	createTestJobs(t, store, jobs...)
+
+	want := make(map[string][]string)
+	for _, job := range jobs {
+		want[job.OrderingKey] = append(want[job.OrderingKey], job.ID)
+	}
+
+	var (
+		mu       sync.Mutex
+		got      = make(map[string][]string)
+		inFlight = make(map[string]string)
+		errs     []error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(workerID string) {
+			defer wg.Done()
+			idle := 0
+			for idle < 20 {
+				claim, err := store.GetPendingJob(interfaces.ClaimFilter{WorkerID: workerID})
+				if err != nil {
+					fail(fmt.Errorf("GetPendingJob failed: %w", err))
+					return
+				}
+				job := claim.Job
+				if job == nil {
+					idle++
+					time.Sleep(10 * time.Millisecond)
+					continue
+				}
+				idle = 0
+
+				mu.Lock()
+				if other, ok := inFlight[job.OrderingKey]; ok {
+					errs = append(errs, fmt.Errorf("job %s of %s claimed while %s is still processing", job.ID, job.OrderingKey, other))
+				}
+				inFlight[job.OrderingKey] = job.ID
+				got[job.OrderingKey] = append(got[job.OrderingKey], job.ID)
+				mu.Unlock()
+
+				// The job stays processing until the update below commits, so its key is free to
+				// forget before then and no later job of the key may be claimed meanwhile
+				time.Sleep(time.Millisecond)
+				mu.Lock()
+				delete(inFlight, job.OrderingKey)
+				mu.Unlock()
+
+				job.Status = interfaces.StatusCompleted
+				if _, _, err := store.UpdateJobAndResolveDependents(job); err != nil {
+					fail(fmt.Errorf("failed to complete job %s: %w", job.ID, err))
+					return
+				}
+			}
+		}(fmt.Sprintf("worker-%d", w))
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		t.Error(err)
+	}
+	for key, ids := range want {
+		if fmt.Sprint(got[key]) != fmt.Sprint(ids) {
+			t.Errorf("%s processed in order %v, want %v", key, got[key], ids)
+		}
+	}
+}
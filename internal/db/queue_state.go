@@ -0,0 +1,61 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// SetQueueState stores the state of a queue or job type; an active state removes the row
+func (s *Store) SetQueueState(state *interfaces.QueueState) error {
+	if state.State == interfaces.QueueActive {
+		query := `DELETE FROM queue_state WHERE kind = $1 AND name = $2`
+		if _, err := s.db.Exec(query, state.Kind, state.Name); err != nil {
+			return fmt.Errorf("failed to clear queue state: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		INSERT INTO queue_state (kind, name, state, reason, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (kind, name) DO UPDATE
+		SET state = EXCLUDED.state, reason = EXCLUDED.reason, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := s.db.Exec(query, state.Kind, state.Name, state.State, nullString(state.Reason), state.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set queue state: %w", err)
+	}
+
+	return nil
+}
+
+// GetQueueStates retrieves every paused or draining queue and job type
+func (s *Store) GetQueueStates() ([]*interfaces.QueueState, error) {
+	query := `SELECT kind, name, state, reason, updated_at FROM queue_state ORDER BY kind, name`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query queue states: %w", err)
+	}
+	defer rows.Close()
+
+	var states []*interfaces.QueueState
+	for rows.Next() {
+		state := &interfaces.QueueState{}
+		var reason sql.NullString
+		if err := rows.Scan(&state.Kind, &state.Name, &state.State, &reason, &state.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan queue state: %w", err)
+		}
+		state.Reason = reason.String
+		states = append(states, state)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return states, nil
+}
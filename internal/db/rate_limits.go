@@ -0,0 +1,150 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// availableTokens is the SQL expression for the tokens in a bucket after refilling it up to now.
+// Its columns are qualified so it reads the stored row in an upsert and inside queries on jobs.
+const availableTokens = `LEAST(rate_limits.burst::double precision,
+	rate_limits.tokens + GREATEST(EXTRACT(EPOCH FROM (NOW() - rate_limits.updated_at)), 0)
+		* rate_limits.limit_count / rate_limits.period_seconds)`
+
+// SetRateLimit creates or replaces a rate limit; a new bucket starts full and an existing one
+// keeps the tokens it has refilled so far, up to the new burst
+func (s *Store) SetRateLimit(limit *interfaces.RateLimit) error {
+	period, ok := interfaces.RateLimitPeriods[limit.Period]
+	if !ok {
+		return fmt.Errorf("unknown rate limit period: %s", limit.Period)
+	}
+
+	query := `
+		INSERT INTO rate_limits (kind, name, limit_count, period_seconds, burst, tokens, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5, $6)
+		ON CONFLICT (kind, name) DO UPDATE
+		SET limit_count = EXCLUDED.limit_count,
+			period_seconds = EXCLUDED.period_seconds,
+			burst = EXCLUDED.burst,
+			tokens = LEAST(` + availableTokens + `, EXCLUDED.burst),
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := s.db.Exec(query, limit.Kind, limit.Name, limit.Limit, int(period/time.Second), limit.Burst, limit.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set rate limit: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRateLimit removes the rate limit of a queue or job type
+func (s *Store) DeleteRateLimit(kind interfaces.QueueControlKind, name string) error {
+	query := `DELETE FROM rate_limits WHERE kind = $1 AND name = $2`
+	if _, err := s.db.Exec(query, kind, name); err != nil {
+		return fmt.Errorf("failed to delete rate limit: %w", err)
+	}
+	return nil
+}
+
+// GetRateLimits retrieves every rate limit with the tokens available right now
+func (s *Store) GetRateLimits() ([]*interfaces.RateLimit, error) {
+	query := `
+		SELECT kind, name, limit_count, period_seconds, burst, ` + availableTokens + `, updated_at
+		FROM rate_limits
+		ORDER BY kind, name
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rate limits: %w", err)
+	}
+	defer rows.Close()
+
+	var limits []*interfaces.RateLimit
+	for rows.Next() {
+		limit := &interfaces.RateLimit{}
+		var periodSeconds int
+		err := rows.Scan(&limit.Kind, &limit.Name, &limit.Limit, &periodSeconds, &limit.Burst, &limit.Tokens, &limit.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rate limit: %w", err)
+		}
+		limit.Period = periodName(periodSeconds)
+		limits = append(limits, limit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return limits, nil
+}
+
+// periodName maps a stored period length back to its name
+func periodName(seconds int) string {
+	for name, period := range interfaces.RateLimitPeriods {
+		if int(period/time.Second) == seconds {
+			return name
+		}
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// takeRateTokens takes one token from every bucket limiting the job's type and queue.
+// If a bucket is empty it returns that bucket's kind and name and the caller must roll back.
+func takeRateTokens(tx *sql.Tx, job *interfaces.Job) (interfaces.QueueControlKind, string, error) {
+	query := `
+		SELECT kind, name FROM rate_limits
+		WHERE (kind = 'type' AND name = $1) OR (kind = 'queue' AND name = $2)
+	`
+
+	rows, err := tx.Query(query, job.Type, job.Queue)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to query rate limits: %w", err)
+	}
+
+	type bucket struct {
+		kind interfaces.QueueControlKind
+		name string
+	}
+	var buckets []bucket
+	for rows.Next() {
+		var b bucket
+		if err := rows.Scan(&b.kind, &b.name); err != nil {
+			rows.Close()
+			return "", "", fmt.Errorf("failed to scan rate limit: %w", err)
+		}
+		buckets = append(buckets, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return "", "", fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	// The row lock taken by the update serializes claims sharing a bucket across all workers
+	updateQuery := `
+		UPDATE rate_limits
+		SET tokens = ` + availableTokens + ` - 1,
+			updated_at = GREATEST(updated_at, NOW())
+		WHERE kind = $1 AND name = $2 AND ` + availableTokens + ` >= 1
+	`
+
+	for _, b := range buckets {
+		result, err := tx.Exec(updateQuery, b.kind, b.name)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to take rate limit token: %w", err)
+		}
+		taken, err := result.RowsAffected()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to take rate limit token: %w", err)
+		}
+		if taken == 0 {
+			return b.kind, b.name, nil
+		}
+	}
+
+	return "", "", nil
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// setTestRateLimit stores a rate limit whose bucket starts full
+func setTestRateLimit(t *testing.T, store *Store, kind interfaces.QueueControlKind, name string, limit int, period string, burst int) {
+	t.Helper()
+	err := store.SetRateLimit(&interfaces.RateLimit{
+		Kind: kind, Name: name, Limit: limit, Period: period, Burst: burst, UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("SetRateLimit failed: %v", err)
+	}
+}
+
+func TestRateLimitEmptiesBucket(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    interfaces.QueueControlKind
+		limited string
+	}{
+		{"type bucket", interfaces.QueueControlType, "limited"},
+		{"queue bucket", interfaces.QueueControlQueue, "limited"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := openTestStore(t)
+
+			var limited []*interfaces.Job
+			for i := 0; i < 3; i++ {
+				if tt.kind == interfaces.QueueControlType {
+					limited = append(limited, newTestJob(tt.limited, "default"))
+				} else {
+					limited = append(limited, newTestJob("echo", tt.limited))
+				}
+			}
+			free := newTestJob("free", "default")
+			createTestJobs(t, store, append(limited, free)...)
+			setTestRateLimit(t, store, tt.kind, tt.limited, 2, "minute", 2)
+
+			filter := interfaces.ClaimFilter{WorkerID: "w1"}
+			for _, job := range limited[:2] {
+				if got := claimID(t, store, filter); got != job.ID {
+					t.Fatalf("claimed %q, want %s while the bucket has tokens", got, job.ID)
+				}
+			}
+			// The third limited job is older, but its bucket is empty
+			if got := claimID(t, store, filter); got != free.ID {
+				t.Fatalf("claimed %q, want the unlimited job %s", got, free.ID)
+			}
+			if got := claimID(t, store, filter); got != "" {
+				t.Fatalf("claimed %s, want nothing until the bucket refills", got)
+			}
+
+			limits, err := store.GetRateLimits()
+			if err != nil {
+				t.Fatalf("GetRateLimits failed: %v", err)
+			}
+			if len(limits) != 1 || limits[0].Period != "minute" || limits[0].Tokens >= 1 {
+				t.Errorf("GetRateLimits() = %+v, want one minute bucket with less than a token", limits)
+			}
+		})
+	}
+}
+
+func TestRateLimitRefills(t *testing.T) {
+	store := openTestStore(t)
+
+	first := newTestJob("limited", "default")
+	second := newTestJob("limited", "default")
+	createTestJobs(t, store, first, second)
+	setTestRateLimit(t, store, interfaces.QueueControlType, "limited", 1, "second", 1)
+
+	filter := interfaces.ClaimFilter{WorkerID: "w1"}
+	if got := claimID(t, store, filter); got != first.ID {
+		t.Fatalf("claimed %q, want %s", got, first.ID)
+	}
+	if got := claimID(t, store, filter); got != "" {
+		t.Fatalf("claimed %s, want nothing right after the bucket emptied", got)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	if got := claimID(t, store, filter); got != second.ID {
+		t.Fatalf("claimed %q, want %s once the bucket refilled", got, second.ID)
+	}
+}
+
+func TestSetRateLimitKeepsRefilledTokens(t *testing.T) {
+	store := openTestStore(t)
+
+	first := newTestJob("limited", "default")
+	createTestJobs(t, store, first)
+	setTestRateLimit(t, store, interfaces.QueueControlType, "limited", 1, "second", 1)
+	if got := claimID(t, store, interfaces.ClaimFilter{WorkerID: "w1"}); got != first.ID {
+		t.Fatalf("claimed %q, want %s", got, first.ID)
+	}
+
+	// The bucket refills while empty, and raising the burst must not throw that away
+	time.Sleep(1100 * time.Millisecond)
+	setTestRateLimit(t, store, interfaces.QueueControlType, "limited", 1, "second", 5)
+
+	limits, err := store.GetRateLimits()
+	if err != nil {
+		t.Fatalf("GetRateLimits failed: %v", err)
+	}
+	if len(limits) != 1 || limits[0].Burst != 5 || limits[0].Tokens < 1 {
+		t.Errorf("GetRateLimits() = %+v, want a bucket of 5 holding the token refilled meanwhile", limits)
+	}
+}
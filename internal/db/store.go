@@ -2,12 +2,120 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
 	"github.com/mtr002/Job-Queue/internal/interfaces"
 )
 
+// jobColumns lists the jobs columns in the order scanJob expects them.
+// The payload is read back as text, so payloads stored as JSON strings come back unquoted.
+const jobColumns = `id, type, payload #>> '{}' AS payload, status, queue, ordering_key, result, error, attempts, max_attempts, priority, retry_after, batch_id, workflow_id, dependency_policy,
+	compensation_type, compensation_payload, compensation_status, compensation_job_id, compensates_job_id,
+	unique_key, unique_scope, unique_until, expires_at, progress, progress_message, checkpoint, claimed_by, claim_token, created_at, updated_at`
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanJob reads a single job selected with jobColumns
+func scanJob(row rowScanner) (*interfaces.Job, error) {
+	job := &interfaces.Job{}
+	var retryAfter sql.NullTime
+	var batchID, workflowID sql.NullString
+	var compensationType, compensationPayload, compensationStatus, compensationJobID, compensatesJobID sql.NullString
+	var orderingKey, uniqueKey, uniqueScope sql.NullString
+	var uniqueUntil, expiresAt sql.NullTime
+	var progressMessage, checkpoint, claimedBy, claimToken sql.NullString
+
+	err := row.Scan(
+		&job.ID, &job.Type, &job.Payload, &job.Status, &job.Queue, &orderingKey, &job.Result, &job.Error,
+		&job.Attempts, &job.MaxAttempts, &job.Priority, &retryAfter, &batchID, &workflowID, &job.DependencyPolicy,
+		&compensationType, &compensationPayload, &compensationStatus, &compensationJobID, &compensatesJobID,
+		&uniqueKey, &uniqueScope, &uniqueUntil, &expiresAt, &job.Progress, &progressMessage, &checkpoint,
+		&claimedBy, &claimToken, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if retryAfter.Valid {
+		job.RetryAfter = &retryAfter.Time
+	}
+	job.OrderingKey = orderingKey.String
+	job.BatchID = batchID.String
+	job.WorkflowID = workflowID.String
+	job.CompensationType = compensationType.String
+	job.CompensationPayload = compensationPayload.String
+	job.CompensationStatus = interfaces.CompensationStatus(compensationStatus.String)
+	job.CompensationJobID = compensationJobID.String
+	job.CompensatesJobID = compensatesJobID.String
+	job.UniqueKey = uniqueKey.String
+	job.UniqueScope = interfaces.UniqueScope(uniqueScope.String)
+	if uniqueUntil.Valid {
+		job.UniqueUntil = &uniqueUntil.Time
+	}
+	if expiresAt.Valid {
+		job.ExpiresAt = &expiresAt.Time
+	}
+	job.ProgressMessage = progressMessage.String
+	job.Checkpoint = checkpoint.String
+	job.ClaimedBy = claimedBy.String
+	job.ClaimToken = claimToken.String
+
+	return job, nil
+}
+
+// scanJobs reads every job from rows selected with jobColumns
+func scanJobs(rows *sql.Rows) ([]*interfaces.Job, error) {
+	var jobs []*interfaces.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// queueName returns the queue a job is stored in
+func queueName(job *interfaces.Job) string {
+	if job.Queue == "" {
+		return interfaces.DefaultQueue
+	}
+	return job.Queue
+}
+
+// payloadJSON returns the form a payload is stored in; text that is not JSON is stored as a JSON string
+func payloadJSON(payload string) string {
+	if json.Valid([]byte(payload)) {
+		return payload
+	}
+	encoded, _ := json.Marshal(payload)
+	return string(encoded)
+}
+
+// nullString stores empty strings as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 // Store handles database operations for jobs
 type Store struct {
 	db *sql.DB
@@ -18,16 +126,63 @@ func NewStore(db *sql.DB) *Store {
 	return &Store{db: db}
 }
 
-// CreateJob inserts a new job into the database
+// CreateJob inserts a new job into the database.
+// Jobs with dependencies are stored together with their edges and start out blocked
+// unless every job they depend on has already finished.
+// A job whose unique key is held by another job is not stored and a DuplicateJobError is returned.
 func (s *Store) CreateJob(job *interfaces.Job) error {
+	if len(job.DependsOn) == 0 && job.UniqueKey == "" {
+		return insertJob(s.db, job)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if job.UniqueKey != "" {
+		if err := claimUniqueKey(tx, job); err != nil {
+			return err
+		}
+	}
+	if len(job.DependsOn) > 0 {
+		if err := resolveNewJobDependencies(tx, job); err != nil {
+			return err
+		}
+	}
+	if err := insertJob(tx, job); err != nil {
+		return err
+	}
+	if len(job.DependsOn) > 0 {
+		if err := insertDependencies(tx, job); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertJob writes a single job row
+func insertJob(e execer, job *interfaces.Job) error {
 	query := `
-		INSERT INTO jobs (id, type, payload, status, result, error, attempts, max_attempts, retry_after, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO jobs (id, type, payload, status, queue, ordering_key, result, error, attempts, max_attempts,
+			priority, retry_after, batch_id, workflow_id, dependency_policy, compensation_type, compensation_payload,
+			compensates_job_id, unique_key, unique_scope, unique_until, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
 	`
 
-	_, err := s.db.Exec(query,
-		job.ID, job.Type, job.Payload, job.Status, job.Result, job.Error,
-		job.Attempts, job.MaxAttempts, job.RetryAfter, job.CreatedAt, job.UpdatedAt)
+	_, err := e.Exec(query,
+		job.ID, job.Type, payloadJSON(job.Payload), job.Status, queueName(job), nullString(job.OrderingKey), job.Result, job.Error,
+		job.Attempts, job.MaxAttempts, job.Priority, job.RetryAfter, nullString(job.BatchID),
+		nullString(job.WorkflowID), dependencyPolicy(job), nullString(job.CompensationType),
+		nullString(job.CompensationPayload), nullString(job.CompensatesJobID),
+		nullString(job.UniqueKey), nullString(string(job.UniqueScope)), job.UniqueUntil, job.ExpiresAt,
+		job.CreatedAt, job.UpdatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create job: %w", err)
@@ -36,20 +191,66 @@ func (s *Store) CreateJob(job *interfaces.Job) error {
 	return nil
 }
 
-// GetJob retrieves a job by ID
-func (s *Store) GetJob(id string) (*interfaces.Job, error) {
-	query := `
-		SELECT id, type, payload, status, result, error, attempts, max_attempts, retry_after, created_at, updated_at
-		FROM jobs WHERE id = $1
-	`
+// CreateJobs bulk inserts jobs in a single transaction using COPY
+func (s *Store) CreateJobs(jobs []*interfaces.Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
 
-	job := &interfaces.Job{}
-	var retryAfter sql.NullTime
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := copyJobs(tx, jobs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// copyJobs streams jobs into the jobs table with COPY inside tx
+func copyJobs(tx *sql.Tx, jobs []*interfaces.Job) error {
+	stmt, err := tx.Prepare(pq.CopyIn("jobs",
+		"id", "type", "payload", "status", "queue", "ordering_key", "result", "error",
+		"attempts", "max_attempts", "priority", "retry_after", "batch_id", "dependency_policy",
+		"compensation_type", "compensation_payload", "expires_at", "created_at", "updated_at"))
+	if err != nil {
+		return fmt.Errorf("failed to prepare copy: %w", err)
+	}
+
+	for _, job := range jobs {
+		_, err := stmt.Exec(
+			job.ID, job.Type, payloadJSON(job.Payload), job.Status, queueName(job), nullString(job.OrderingKey), job.Result, job.Error,
+			job.Attempts, job.MaxAttempts, job.Priority, job.RetryAfter, nullString(job.BatchID), dependencyPolicy(job),
+			nullString(job.CompensationType), nullString(job.CompensationPayload), job.ExpiresAt, job.CreatedAt, job.UpdatedAt)
+		if err != nil {
+			stmt.Close()
+			return fmt.Errorf("failed to copy job %s: %w", job.ID, err)
+		}
+	}
+
+	if _, err := stmt.Exec(); err != nil {
+		stmt.Close()
+		return fmt.Errorf("failed to flush copy: %w", err)
+	}
+	if err := stmt.Close(); err != nil {
+		return fmt.Errorf("failed to close copy: %w", err)
+	}
 
-	err := s.db.QueryRow(query, id).Scan(
-		&job.ID, &job.Type, &job.Payload, &job.Status, &job.Result, &job.Error,
-		&job.Attempts, &job.MaxAttempts, &retryAfter, &job.CreatedAt, &job.UpdatedAt)
+	return nil
+}
 
+// GetJob retrieves a job by ID
+func (s *Store) GetJob(id string) (*interfaces.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	job, err := scanJob(s.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("job with ID %s not found", id)
@@ -57,8 +258,9 @@ func (s *Store) GetJob(id string) (*interfaces.Job, error) {
 		return nil, fmt.Errorf("failed to get job: %w", err)
 	}
 
-	if retryAfter.Valid {
-		job.RetryAfter = &retryAfter.Time
+	job.DependsOn, err = s.getDependencies(id)
+	if err != nil {
+		return nil, err
 	}
 
 	return job, nil
@@ -66,115 +268,350 @@ func (s *Store) GetJob(id string) (*interfaces.Job, error) {
 
 // UpdateJob updates an existing job
 func (s *Store) UpdateJob(job *interfaces.Job) error {
+	return updateJob(s.db, job)
+}
+
+// updateJob writes the mutable fields of a job. The job must still be under the claim it was read with,
+// so a run whose job was released meanwhile cannot overwrite the outcome of the next run; ErrClaimLost is returned instead.
+func updateJob(e execer, job *interfaces.Job) error {
 	query := `
 		UPDATE jobs 
 		SET status = $2, result = $3, error = $4, attempts = $5, retry_after = $6, updated_at = $7
-		WHERE id = $1
+		WHERE id = $1 AND claim_token IS NOT DISTINCT FROM $8
 	`
 
 	job.UpdatedAt = time.Now()
 
-	_, err := s.db.Exec(query,
-		job.ID, job.Status, job.Result, job.Error, job.Attempts, job.RetryAfter, job.UpdatedAt)
+	result, err := e.Exec(query,
+		job.ID, job.Status, job.Result, job.Error, job.Attempts, job.RetryAfter, job.UpdatedAt, nullString(job.ClaimToken))
 
 	if err != nil {
 		return fmt.Errorf("failed to update job: %w", err)
 	}
 
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("%w: job %s", interfaces.ErrClaimLost, job.ID)
+	}
+
 	return nil
 }
 
-// GetPendingJob retrieves the next pending job for processing
-func (s *Store) GetPendingJob() (*interfaces.Job, error) {
+// UpdateJobProgress writes the progress and checkpoint a handler reported for a running job.
+// Jobs that are no longer processing, or were claimed again since, are left alone so a late report cannot overwrite their outcome.
+func (s *Store) UpdateJobProgress(job *interfaces.Job) error {
+	query := `
+		UPDATE jobs
+		SET progress = $2, progress_message = $3, checkpoint = $4, updated_at = $5
+		WHERE id = $1 AND status = 'processing' AND claim_token IS NOT DISTINCT FROM $6
+	`
+
+	job.UpdatedAt = time.Now()
+
+	_, err := s.db.Exec(query,
+		job.ID, job.Progress, nullString(job.ProgressMessage), nullString(job.Checkpoint), job.UpdatedAt, nullString(job.ClaimToken))
+	if err != nil {
+		return fmt.Errorf("failed to update job progress: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseJob hands a job that is still processing under the given claim back to the queue without counting an attempt.
+// Its progress and checkpoint are kept so the next run can resume; nil is returned if the job is no longer processing under that claim.
+func (s *Store) ReleaseJob(id, claimToken string) (*interfaces.Release, error) {
 	tx, err := s.db.Begin()
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	// Get the next pending job or a job that's ready for retry
+	release, err := releaseJob(tx, id, claimToken)
+	if err != nil || release == nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return release, nil
+}
+
+// releaseJob sends a processing job back to pending. A job with a pending scoped unique key gave the key up when it
+// started, so if another job holds the key now it is cancelled as a duplicate instead, which the unique index requires.
+func releaseJob(tx *sql.Tx, id, claimToken string) (*interfaces.Release, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE id = $1 AND status = 'processing' AND claim_token IS NOT DISTINCT FROM $2
+		FOR UPDATE
+	`
+
+	job, err := scanJob(tx.QueryRow(query, id, nullString(claimToken)))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if job.UniqueKey != "" && job.UniqueScope == interfaces.UniquePending {
+		err := claimUniqueKey(tx, job)
+		var dup *interfaces.DuplicateJobError
+		if errors.As(err, &dup) {
+			return cancelDuplicate(tx, job, dup)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query = `UPDATE jobs SET status = 'pending', claimed_by = NULL, claim_token = NULL, updated_at = NOW() WHERE id = $1 RETURNING ` + jobColumns
+
+	job, err = scanJob(tx.QueryRow(query, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to release job: %w", err)
+	}
+
+	return &interfaces.Release{Job: job}, nil
+}
+
+// cancelDuplicate cancels a released job whose unique key another job holds, resolving its dependents and batch
+func cancelDuplicate(tx *sql.Tx, job *interfaces.Job, dup *interfaces.DuplicateJobError) (*interfaces.Release, error) {
 	query := `
-		SELECT id, type, payload, status, result, error, attempts, max_attempts, retry_after, created_at, updated_at
+		UPDATE jobs
+		SET status = 'cancelled', error = $2, retry_after = NULL, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(tx.QueryRow(query, job.ID, "job was released while its "+dup.Error()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel duplicate job: %w", err)
+	}
+
+	resolved, err := resolveDependents(tx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	finished, err := recordBatchOutcomes(tx, append([]*interfaces.Job{job}, resolved...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.Release{Job: job, Cancelled: true, Resolved: resolved, FinishedBatches: finished}, nil
+}
+
+// maxClaimAttempts bounds how many limited job types or queues a single claim skips before giving up
+const maxClaimAttempts = 5
+
+// GetPendingJob claims the next pending job matching the filter for processing.
+// Jobs whose queue or type is paused, running at its concurrency limit or out of rate limit tokens are left alone,
+// as are jobs waiting for an earlier job with the same ordering key to finish and jobs past their deadline.
+func (s *Store) GetPendingJob(filter interfaces.ClaimFilter) (*interfaces.Claim, error) {
+	// Without a worker ID the job could never be released if its worker dies
+	if filter.WorkerID == "" {
+		return nil, errors.New("claiming a job needs a worker ID")
+	}
+
+	// Copied so skipped types and queues do not leak into the caller's filter; also never nil, which ANY needs
+	excludeTypes := append([]string{}, filter.ExcludeTypes...)
+	excludeQueues := append([]string{}, filter.ExcludeQueues...)
+
+	claim := &interfaces.Claim{}
+	for attempt := 0; attempt < maxClaimAttempts; attempt++ {
+		job, refusal, err := s.claimPendingJob(filter.Queues, excludeTypes, excludeQueues, filter.WorkerID)
+		if err != nil {
+			return nil, err
+		}
+		if refusal == nil {
+			claim.Job = job
+			return claim, nil
+		}
+
+		if refusal.rateLimited {
+			claim.RateLimited = append(claim.RateLimited, &interfaces.RateLimit{Kind: refusal.kind, Name: refusal.name})
+		}
+		if refusal.kind == interfaces.QueueControlQueue {
+			excludeQueues = append(excludeQueues, refusal.name)
+		} else {
+			excludeTypes = append(excludeTypes, refusal.name)
+		}
+	}
+
+	return claim, nil
+}
+
+// claimRefusal is the queue or type whose limit kept a claim from taking the job it found
+type claimRefusal struct {
+	kind interfaces.QueueControlKind
+	name string
+	// rateLimited is set when a rate limit bucket was empty rather than the type at its concurrency limit
+	rateLimited bool
+}
+
+// runnableJob is the SQL condition for a job that a worker could claim right now: it is due and not expired,
+// heads its ordering key, and neither its queue nor its type is paused, at its concurrency limit
+// or out of rate limit tokens
+const runnableJob = `((status = 'pending') OR (status = 'retrying' AND retry_after <= NOW()))
+	AND (expires_at IS NULL OR expires_at > NOW())
+	AND (ordering_key IS NULL OR NOT EXISTS (
+		SELECT 1 FROM jobs earlier
+		WHERE earlier.ordering_key = jobs.ordering_key
+			AND earlier.sequence < jobs.sequence
+			AND earlier.status IN ('pending', 'blocked', 'processing', 'retrying')
+	))
+	AND NOT EXISTS (
+		SELECT 1 FROM queue_state qs
+		WHERE qs.state = 'paused'
+			AND ((qs.kind = 'queue' AND qs.name = jobs.queue) OR (qs.kind = 'type' AND qs.name = jobs.type))
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM job_type_limits l
+		WHERE l.type = jobs.type
+			AND l.max_concurrency <= (SELECT COUNT(*) FROM jobs p WHERE p.type = l.type AND p.status = 'processing')
+	)
+	AND NOT EXISTS (
+		SELECT 1 FROM rate_limits
+		WHERE ((kind = 'type' AND name = jobs.type) OR (kind = 'queue' AND name = jobs.queue))
+			AND ` + availableTokens + ` < 1
+	)`
+
+// claimPendingJob claims the oldest runnable job outside the excluded types and queues for the given worker under a new claim token.
+// If the job it found is held back by a concurrency or rate limit, nothing is claimed and the refusal is returned instead.
+func (s *Store) claimPendingJob(queues, excludeTypes, excludeQueues []string, workerID string) (*interfaces.Job, *claimRefusal, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Get the most important pending job or job that's ready for retry, oldest first within a priority
+	query := `
+		SELECT ` + jobColumns + `
 		FROM jobs 
-		WHERE (status = 'pending') OR (status = 'retrying' AND retry_after <= NOW())
-		ORDER BY created_at ASC
+		WHERE ` + runnableJob + `
+			AND (COALESCE(cardinality($1::text[]), 0) = 0 OR queue = ANY($1))
+			AND NOT (type = ANY($2))
+			AND NOT (queue = ANY($3))
+		ORDER BY priority DESC, created_at ASC
 		LIMIT 1
 		FOR UPDATE SKIP LOCKED
 	`
 
-	job := &interfaces.Job{}
-	var retryAfter sql.NullTime
-
-	err = tx.QueryRow(query).Scan(
-		&job.ID, &job.Type, &job.Payload, &job.Status, &job.Result, &job.Error,
-		&job.Attempts, &job.MaxAttempts, &retryAfter, &job.CreatedAt, &job.UpdatedAt)
-
+	job, err := scanJob(tx.QueryRow(query, pq.Array(queues), pq.Array(excludeTypes), pq.Array(excludeQueues)))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil // No pending jobs
+			return nil, nil, nil // No pending jobs
 		}
-		return nil, fmt.Errorf("failed to get pending job: %w", err)
+		return nil, nil, fmt.Errorf("failed to get pending job: %w", err)
 	}
 
-	if retryAfter.Valid {
-		job.RetryAfter = &retryAfter.Time
+	// The limit check above may race with other workers, so confirm it under the type's lock
+	ok, err := acquireTypeSlot(tx, job.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, &claimRefusal{kind: interfaces.QueueControlType, name: job.Type}, nil
+	}
+
+	limitedKind, limitedName, err := takeRateTokens(tx, job)
+	if err != nil {
+		return nil, nil, err
+	}
+	if limitedName != "" {
+		return nil, &claimRefusal{kind: limitedKind, name: limitedName, rateLimited: true}, nil
 	}
 
 	// Mark as processing
 	job.Status = interfaces.StatusProcessing
+	job.ClaimedBy = workerID
+	job.ClaimToken = uuid.New().String()
 	job.UpdatedAt = time.Now()
 
-	updateQuery := `UPDATE jobs SET status = $2, updated_at = $3 WHERE id = $1`
-	_, err = tx.Exec(updateQuery, job.ID, job.Status, job.UpdatedAt)
+	updateQuery := `UPDATE jobs SET status = $2, claimed_by = $3, claim_token = $4, updated_at = $5 WHERE id = $1`
+	_, err = tx.Exec(updateQuery, job.ID, job.Status, nullString(job.ClaimedBy), job.ClaimToken, job.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("failed to mark job as processing: %w", err)
+		return nil, nil, fmt.Errorf("failed to mark job as processing: %w", err)
 	}
 
 	if err = tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return job, nil
+	return job, nil, nil
 }
 
-// GetAllJobs retrieves all jobs
-func (s *Store) GetAllJobs() ([]*interfaces.Job, error) {
-	query := `
-		SELECT id, type, payload, status, result, error, attempts, max_attempts, retry_after, created_at, updated_at
-		FROM jobs ORDER BY created_at DESC
-	`
-
-	rows, err := s.db.Query(query)
+// ExpireJobs marks up to limit jobs that passed their deadline before starting as expired, resolving their
+// dependents and batches in the same transaction. Each job is returned by exactly one caller, however many
+// workers expire jobs at once.
+func (s *Store) ExpireJobs(limit int) ([]*interfaces.Expiry, error) {
+	tx, err := s.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to query jobs: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer rows.Close()
-
-	var jobs []*interfaces.Job
-	for rows.Next() {
-		job := &interfaces.Job{}
-		var retryAfter sql.NullTime
+	defer tx.Rollback()
 
-		err := rows.Scan(
-			&job.ID, &job.Type, &job.Payload, &job.Status, &job.Result, &job.Error,
-			&job.Attempts, &job.MaxAttempts, &retryAfter, &job.CreatedAt, &job.UpdatedAt)
+	query := `
+		UPDATE jobs
+		SET status = 'expired', error = 'job expired before it could start', retry_after = NULL, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE expires_at <= NOW() AND status IN ('pending', 'blocked', 'retrying')
+			ORDER BY expires_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	rows, err := tx.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire jobs: %w", err)
+	}
+	expired, err := scanJobs(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
 
+	expiries := make([]*interfaces.Expiry, 0, len(expired))
+	for _, job := range expired {
+		resolved, err := resolveDependents(tx, job.ID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan job: %w", err)
+			return nil, err
 		}
 
-		if retryAfter.Valid {
-			job.RetryAfter = &retryAfter.Time
+		finished, err := recordBatchOutcomes(tx, append([]*interfaces.Job{job}, resolved...)...)
+		if err != nil {
+			return nil, err
 		}
 
-		jobs = append(jobs, job)
+		expiries = append(expiries, &interfaces.Expiry{Job: job, Resolved: resolved, FinishedBatches: finished})
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return jobs, nil
+	return expiries, nil
+}
+
+// GetAllJobs retrieves all jobs
+func (s *Store) GetAllJobs() ([]*interfaces.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs ORDER BY created_at DESC`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
 }
 
 // DeleteJob removes a job from the database
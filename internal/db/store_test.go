@@ -0,0 +1,187 @@
+package db
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// openTestStore connects to the database named by TEST_DATABASE_URL, migrates it and empties every table.
+// Tests using it are skipped when the variable is unset.
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	database, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	if err := RunMigrations(database); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	// Empty every table but goose's own, whichever migrations created them
+	_, err = database.Exec(`DO $$
+		DECLARE tables text;
+		BEGIN
+			SELECT string_agg(quote_ident(tablename), ', ') INTO tables
+			FROM pg_tables WHERE schemaname = current_schema() AND tablename <> 'goose_db_version';
+			EXECUTE 'TRUNCATE ' || tables || ' CASCADE';
+		END $$`)
+	if err != nil {
+		t.Fatalf("failed to empty tables: %v", err)
+	}
+
+	return NewStore(database)
+}
+
+// newTestJob returns a pending job of the given type in the given queue
+func newTestJob(jobType, queue string) *interfaces.Job {
+	return &interfaces.Job{
+		ID:          uuid.New().String(),
+		Type:        jobType,
+		Payload:     "{}",
+		Status:      interfaces.StatusPending,
+		Queue:       queue,
+		MaxAttempts: 3,
+	}
+}
+
+// createTestJobs stores the jobs one by one, each created a millisecond after the one before
+func createTestJobs(t *testing.T, store *Store, jobs ...*interfaces.Job) {
+	t.Helper()
+	start := time.Now()
+	for i, job := range jobs {
+		job.CreatedAt = start.Add(time.Duration(i) * time.Millisecond)
+		job.UpdatedAt = job.CreatedAt
+		if err := store.CreateJob(job); err != nil {
+			t.Fatalf("failed to create job: %v", err)
+		}
+	}
+}
+
+// claimID claims the next job matching filter and returns its ID, or "" if none can be claimed
+func claimID(t *testing.T, store *Store, filter interfaces.ClaimFilter) string {
+	t.Helper()
+	claim, err := store.GetPendingJob(filter)
+	if err != nil {
+		t.Fatalf("GetPendingJob failed: %v", err)
+	}
+	job := claim.Job
+	if job == nil {
+		return ""
+	}
+	if job.Status != interfaces.StatusProcessing || job.ClaimedBy != filter.WorkerID || job.ClaimToken == "" {
+		t.Errorf("claimed job %s is %s for %q, want processing under a claim token for %q", job.ID, job.Status, job.ClaimedBy, filter.WorkerID)
+	}
+	return job.ID
+}
+
+func TestGetPendingJobFilters(t *testing.T) {
+	store := openTestStore(t)
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	expired := newTestJob("echo", "default")
+	expired.ExpiresAt = &past
+	backingOff := newTestJob("echo", "default")
+	backingOff.Status = interfaces.StatusRetrying
+	backingOff.RetryAfter = &future
+	paused := newTestJob("paused", "default")
+	excluded := newTestJob("excluded", "default")
+	otherQueue := newTestJob("echo", "reports")
+	limitedRunning := newTestJob("limited", "default")
+	limitedWaiting := newTestJob("limited", "default")
+	oldest := newTestJob("echo", "default")
+	urgent := newTestJob("echo", "default")
+	urgent.Priority = 5
+	retryDue := newTestJob("echo", "default")
+	retryDue.Status = interfaces.StatusRetrying
+	retryDue.RetryAfter = &past
+	createTestJobs(t, store, expired, backingOff, paused, excluded, otherQueue, limitedRunning, limitedWaiting, oldest, urgent, retryDue)
+
+	err := store.SetQueueState(&interfaces.QueueState{
+		Kind: interfaces.QueueControlType, Name: "paused", State: interfaces.QueuePaused, UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("SetQueueState failed: %v", err)
+	}
+	if err := store.SetTypeLimit(&interfaces.TypeLimit{Type: "limited", MaxConcurrency: 1, UpdatedAt: time.Now()}); err != nil {
+		t.Fatalf("SetTypeLimit failed: %v", err)
+	}
+
+	// Only jobs of the limited type left, so the first one takes its only slot
+	if got := claimID(t, store, interfaces.ClaimFilter{
+		Queues: []string{"default"}, ExcludeTypes: []string{"echo", "excluded"}, WorkerID: "w1",
+	}); got != limitedRunning.ID {
+		t.Fatalf("claimed %q, want the first limited job %s", got, limitedRunning.ID)
+	}
+
+	filter := interfaces.ClaimFilter{Queues: []string{"default"}, ExcludeTypes: []string{"excluded"}, WorkerID: "w1"}
+	want := []string{urgent.ID, oldest.ID, retryDue.ID}
+	for _, id := range want {
+		if got := claimID(t, store, filter); got != id {
+			t.Fatalf("claimed %q, want %s", got, id)
+		}
+	}
+	// What is left in the queue is expired, backing off, paused, excluded or over its type's limit
+	if got := claimID(t, store, filter); got != "" {
+		t.Fatalf("claimed %s, want nothing", got)
+	}
+
+	if got := claimID(t, store, interfaces.ClaimFilter{Queues: []string{"reports"}, WorkerID: "w2"}); got != otherQueue.ID {
+		t.Fatalf("claimed %q from the reports queue, want %s", got, otherQueue.ID)
+	}
+	if got := claimID(t, store, interfaces.ClaimFilter{ExcludeQueues: []string{"default"}, WorkerID: "w2"}); got != "" {
+		t.Fatalf("claimed %s outside the default queue, want nothing", got)
+	}
+}
+
+func TestExpireJobsResolvesDependents(t *testing.T) {
+	store := openTestStore(t)
+
+	past := time.Now().Add(-time.Minute)
+	stale := newTestJob("echo", "default")
+	stale.ExpiresAt = &past
+	dependent := newTestJob("echo", "default")
+	dependent.DependsOn = []string{stale.ID}
+	createTestJobs(t, store, stale, dependent)
+	if dependent.Status != interfaces.StatusBlocked {
+		t.Fatalf("dependent is %s, want blocked", dependent.Status)
+	}
+
+	expiries, err := store.ExpireJobs(10)
+	if err != nil {
+		t.Fatalf("ExpireJobs failed: %v", err)
+	}
+	if len(expiries) != 1 || expiries[0].Job.ID != stale.ID || expiries[0].Job.Status != interfaces.StatusExpired {
+		t.Fatalf("ExpireJobs() = %+v, want the stale job expired", expiries)
+	}
+	if resolved := expiries[0].Resolved; len(resolved) != 1 || resolved[0].Status != interfaces.StatusPermanentFailed {
+		t.Errorf("resolved %+v, want the dependent failed", resolved)
+	}
+
+	current, err := store.GetJob(dependent.ID)
+	if err != nil {
+		t.Fatalf("GetJob failed: %v", err)
+	}
+	if current.Status != interfaces.StatusPermanentFailed {
+		t.Errorf("dependent is %s after the expiry committed, want permanently failed", current.Status)
+	}
+
+	if again, err := store.ExpireJobs(10); err != nil || len(again) != 0 {
+		t.Errorf("ExpireJobs() = %+v, %v on a second run, want nothing left to expire", again, err)
+	}
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// uniqueHolderConditions selects the jobs that still hold a unique key, per scope
+var uniqueHolderConditions = map[interfaces.UniqueScope]string{
+	interfaces.UniquePending:          `status IN ('pending', 'blocked')`,
+	interfaces.UniquePendingOrRunning: `status IN ('pending', 'blocked', 'processing', 'retrying')`,
+	interfaces.UniqueWindow:           `unique_until > NOW()`,
+}
+
+// claimUniqueKey makes sure no other job holds the job's unique key.
+// Submissions sharing a key are serialized on an advisory lock held until the transaction ends,
+// which also covers time window keys that no unique index can express.
+func claimUniqueKey(tx *sql.Tx, job *interfaces.Job) error {
+	condition, ok := uniqueHolderConditions[job.UniqueScope]
+	if !ok {
+		return fmt.Errorf("unknown unique scope: %s", job.UniqueScope)
+	}
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('jobs_unique_key'), hashtext($1))`, job.UniqueKey); err != nil {
+		return fmt.Errorf("failed to lock unique key: %w", err)
+	}
+
+	query := `
+		SELECT id FROM jobs
+		WHERE unique_key = $1 AND unique_scope = $2 AND ` + condition + `
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var existingID string
+	err := tx.QueryRow(query, job.UniqueKey, job.UniqueScope).Scan(&existingID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check unique key: %w", err)
+	}
+
+	return &interfaces.DuplicateJobError{Key: job.UniqueKey, ExistingID: existingID}
+}
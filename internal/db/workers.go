@@ -0,0 +1,219 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+const workerColumns = `id, hostname, pid, version, queues, concurrency, status, running,
+	started_at, last_heartbeat_at, dead_after, stopped_at`
+
+// scanWorker reads a single worker selected with workerColumns
+func scanWorker(row rowScanner) (*interfaces.Worker, error) {
+	worker := &interfaces.Worker{}
+	var running []byte
+	var stoppedAt sql.NullTime
+
+	err := row.Scan(&worker.ID, &worker.Hostname, &worker.PID, &worker.Version, pq.Array(&worker.Queues),
+		&worker.Concurrency, &worker.Status, &running, &worker.StartedAt, &worker.LastHeartbeatAt, &worker.DeadAfter, &stoppedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(running, &worker.Running); err != nil {
+		return nil, fmt.Errorf("failed to decode running jobs of worker %s: %w", worker.ID, err)
+	}
+	if stoppedAt.Valid {
+		worker.StoppedAt = &stoppedAt.Time
+	}
+
+	return worker, nil
+}
+
+func scanWorkers(rows *sql.Rows) ([]*interfaces.Worker, error) {
+	var workers []*interfaces.Worker
+	for rows.Next() {
+		worker, err := scanWorker(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan worker: %w", err)
+		}
+		workers = append(workers, worker)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate workers: %w", err)
+	}
+
+	return workers, nil
+}
+
+// encodeRunning encodes the running jobs of a worker for the running column, never as null
+func encodeRunning(running []*interfaces.WorkerJob) ([]byte, error) {
+	if running == nil {
+		running = []*interfaces.WorkerJob{}
+	}
+	data, err := json.Marshal(running)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode running jobs: %w", err)
+	}
+	return data, nil
+}
+
+// RegisterWorker records a worker process as active with its first heartbeat, due again within timeout.
+// A worker registering again under the same ID, after being declared dead, becomes active again.
+// The database clock sets the heartbeat times so workers on other hosts are compared fairly.
+func (s *Store) RegisterWorker(worker *interfaces.Worker, timeout time.Duration) error {
+	running, err := encodeRunning(worker.Running)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO workers (id, hostname, pid, version, queues, concurrency, status, running,
+			started_at, last_heartbeat_at, dead_after)
+		VALUES ($1, $2, $3, $4, $5, $6, 'active', $7, $8, NOW(), NOW() + make_interval(secs => $9))
+		ON CONFLICT (id) DO UPDATE
+		SET queues = EXCLUDED.queues, concurrency = EXCLUDED.concurrency, status = 'active', running = EXCLUDED.running,
+			last_heartbeat_at = EXCLUDED.last_heartbeat_at, dead_after = EXCLUDED.dead_after, stopped_at = NULL
+		RETURNING status, last_heartbeat_at, dead_after
+	`
+
+	err = s.db.QueryRow(query, worker.ID, worker.Hostname, worker.PID, worker.Version, pq.Array(worker.Queues),
+		worker.Concurrency, running, worker.StartedAt, timeout.Seconds()).
+		Scan(&worker.Status, &worker.LastHeartbeatAt, &worker.DeadAfter)
+	if err != nil {
+		return fmt.Errorf("failed to register worker: %w", err)
+	}
+	worker.StoppedAt = nil
+
+	return nil
+}
+
+// HeartbeatWorker records a heartbeat of an active worker with what it is running, the next one due within timeout.
+// It returns false without recording anything if the worker is no longer active, such as after being declared dead.
+func (s *Store) HeartbeatWorker(worker *interfaces.Worker, timeout time.Duration) (bool, error) {
+	running, err := encodeRunning(worker.Running)
+	if err != nil {
+		return false, err
+	}
+
+	query := `
+		UPDATE workers
+		SET queues = $2, concurrency = $3, running = $4, last_heartbeat_at = NOW(), dead_after = NOW() + make_interval(secs => $5)
+		WHERE id = $1 AND status = 'active'
+		RETURNING last_heartbeat_at, dead_after
+	`
+
+	err = s.db.QueryRow(query, worker.ID, pq.Array(worker.Queues), worker.Concurrency, running, timeout.Seconds()).
+		Scan(&worker.LastHeartbeatAt, &worker.DeadAfter)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record worker heartbeat: %w", err)
+	}
+
+	return true, nil
+}
+
+// StopWorker records that a worker process shut down cleanly
+func (s *Store) StopWorker(id string) error {
+	query := `UPDATE workers SET status = 'stopped', running = '[]', stopped_at = NOW() WHERE id = $1`
+
+	if _, err := s.db.Exec(query, id); err != nil {
+		return fmt.Errorf("failed to stop worker: %w", err)
+	}
+	return nil
+}
+
+// ListWorkers retrieves every registered worker, active ones first and newest first within a status
+func (s *Store) ListWorkers() ([]*interfaces.Worker, error) {
+	query := `SELECT ` + workerColumns + ` FROM workers ORDER BY status = 'active' DESC, started_at DESC`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workers: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWorkers(rows)
+}
+
+// MarkDeadWorkers declares every active worker whose heartbeat is overdue dead and returns them.
+// Each worker is returned by exactly one caller, however many services look for dead workers at once.
+func (s *Store) MarkDeadWorkers() ([]*interfaces.Worker, error) {
+	query := `
+		UPDATE workers
+		SET status = 'dead', stopped_at = dead_after
+		WHERE status = 'active' AND dead_after < NOW()
+		RETURNING ` + workerColumns
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark dead workers: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWorkers(rows)
+}
+
+// ReleaseWorkerJobs sends the jobs a dead worker claimed and did not finish back to the queue.
+// Each job is released in its own transaction, so one that cannot be released does not hold up the others;
+// the releases that succeeded are returned along with the errors of those that did not.
+func (s *Store) ReleaseWorkerJobs(workerID string) ([]*interfaces.Release, error) {
+	query := `SELECT id, claim_token FROM jobs WHERE claimed_by = $1 AND status = 'processing'`
+	rows, err := s.db.Query(query, workerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs of worker %s: %w", workerID, err)
+	}
+	defer rows.Close()
+
+	// Released under the claim they were found with, so a claim the worker made since is left alone
+	type claim struct {
+		id    string
+		token sql.NullString
+	}
+	var claims []claim
+	for rows.Next() {
+		var c claim
+		if err := rows.Scan(&c.id, &c.token); err != nil {
+			return nil, fmt.Errorf("failed to scan job claim: %w", err)
+		}
+		claims = append(claims, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate jobs of worker %s: %w", workerID, err)
+	}
+
+	var releases []*interfaces.Release
+	var errs []error
+	for _, c := range claims {
+		release, err := s.ReleaseJob(c.id, c.token.String)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", c.id, err))
+			continue
+		}
+		if release != nil {
+			releases = append(releases, release)
+		}
+	}
+
+	return releases, errors.Join(errs...)
+}
+
+// DeleteWorkers removes the stopped and dead workers that stopped before the given time
+func (s *Store) DeleteWorkers(stoppedBefore time.Time) (int64, error) {
+	query := `DELETE FROM workers WHERE status <> 'active' AND stopped_at < $1`
+
+	result, err := s.db.Exec(query, stoppedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete workers: %w", err)
+	}
+	return result.RowsAffected()
+}
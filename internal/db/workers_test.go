@@ -0,0 +1,76 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+func TestReleasedClaimCannotFinishJob(t *testing.T) {
+	tests := []struct {
+		name      string
+		reclaimer string
+	}{
+		{"claimed again by another worker", "alive"},
+		// A worker declared dead registers again under the same ID and claims the job it was running
+		{"claimed again by the same worker", "dead"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := openTestStore(t)
+
+			job := newTestJob("echo", "default")
+			createTestJobs(t, store, job)
+
+			claim, err := store.GetPendingJob(interfaces.ClaimFilter{WorkerID: "dead"})
+			if err != nil || claim.Job == nil {
+				t.Fatalf("GetPendingJob() = %+v, %v, want the job", claim, err)
+			}
+			stale := claim.Job
+			releases, err := store.ReleaseWorkerJobs("dead")
+			if err != nil {
+				t.Fatalf("ReleaseWorkerJobs failed: %v", err)
+			}
+			if len(releases) != 1 || releases[0].Job.ID != job.ID || releases[0].Job.Status != interfaces.StatusPending {
+				t.Fatalf("ReleaseWorkerJobs() = %+v, want the job back to pending", releases)
+			}
+
+			if got := claimID(t, store, interfaces.ClaimFilter{WorkerID: tt.reclaimer}); got != job.ID {
+				t.Fatalf("claimed %q, want the released job %s", got, job.ID)
+			}
+
+			// The first run reports late; the job now runs under another claim
+			progress := *stale
+			progress.Progress = 50
+			if err := store.UpdateJobProgress(&progress); err != nil {
+				t.Fatalf("UpdateJobProgress failed: %v", err)
+			}
+			stale.Status = interfaces.StatusCompleted
+			if _, _, err := store.UpdateJobAndResolveDependents(stale); !errors.Is(err, interfaces.ErrClaimLost) {
+				t.Errorf("UpdateJobAndResolveDependents error = %v, want ErrClaimLost", err)
+			}
+			if release, err := store.ReleaseJob(job.ID, stale.ClaimToken); release != nil || err != nil {
+				t.Errorf("ReleaseJob() = %+v, %v, want nothing to release", release, err)
+			}
+
+			current, err := store.GetJob(job.ID)
+			if err != nil {
+				t.Fatalf("GetJob failed: %v", err)
+			}
+			if current.Status != interfaces.StatusProcessing || current.ClaimedBy != tt.reclaimer || current.Progress != 0 {
+				t.Errorf("job is %s at %d%% for %q, want processing at 0%% for %q", current.Status, current.Progress, current.ClaimedBy, tt.reclaimer)
+			}
+		})
+	}
+}
+
+func TestGetPendingJobNeedsWorkerID(t *testing.T) {
+	store := openTestStore(t)
+	createTestJobs(t, store, newTestJob("echo", "default"))
+
+	if claim, err := store.GetPendingJob(interfaces.ClaimFilter{}); err == nil || claim != nil {
+		t.Errorf("GetPendingJob() = %+v, %v, want an error without a worker ID", claim, err)
+	}
+}
@@ -0,0 +1,176 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// CreateWorkflow inserts a workflow, its step jobs and their dependency edges in one transaction.
+// jobs must line up with workflow.Steps and be ordered so that every job follows the jobs it depends on.
+func (s *Store) CreateWorkflow(workflow *interfaces.Workflow, jobs []*interfaces.Job) error {
+	if len(jobs) != len(workflow.Steps) {
+		return fmt.Errorf("workflow has %d steps but %d jobs", len(workflow.Steps), len(jobs))
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO workflows (id, name, status, output_step, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = tx.Exec(query,
+		workflow.ID, workflow.Name, workflow.Status, workflow.OutputStep, workflow.CreatedAt, workflow.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create workflow: %w", err)
+	}
+
+	stepQuery := `
+		INSERT INTO workflow_steps (workflow_id, name, position, job_id, depends_on, input_from)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for i, step := range workflow.Steps {
+		job := jobs[i]
+		if err := insertJob(tx, job); err != nil {
+			return err
+		}
+		if len(job.DependsOn) > 0 {
+			if err := insertDependencies(tx, job); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.Exec(stepQuery,
+			workflow.ID, step.Name, i, job.ID, pq.Array(step.DependsOn), nullString(step.InputFrom))
+		if err != nil {
+			return fmt.Errorf("failed to create workflow step %s: %w", step.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkflow retrieves a workflow with the current state of each step
+func (s *Store) GetWorkflow(id string) (*interfaces.Workflow, error) {
+	query := `
+		SELECT id, name, status, output_step, output, error, created_at, updated_at, completed_at
+		FROM workflows WHERE id = $1
+	`
+
+	workflow := &interfaces.Workflow{}
+	var output, errMsg sql.NullString
+	var completedAt sql.NullTime
+
+	err := s.db.QueryRow(query, id).Scan(
+		&workflow.ID, &workflow.Name, &workflow.Status, &workflow.OutputStep, &output, &errMsg,
+		&workflow.CreatedAt, &workflow.UpdatedAt, &completedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("workflow with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	workflow.Output = output.String
+	workflow.Error = errMsg.String
+	if completedAt.Valid {
+		workflow.CompletedAt = &completedAt.Time
+	}
+
+	stepsQuery := `
+		SELECT s.name, s.job_id, j.type, s.depends_on, s.input_from, j.status, j.result, j.error
+		FROM workflow_steps s JOIN jobs j ON j.id = s.job_id
+		WHERE s.workflow_id = $1
+		ORDER BY s.position ASC
+	`
+
+	rows, err := s.db.Query(stepsQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workflow steps: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		step := &interfaces.WorkflowStep{}
+		var inputFrom, result, stepErr sql.NullString
+
+		err := rows.Scan(
+			&step.Name, &step.JobID, &step.Type, pq.Array(&step.DependsOn), &inputFrom,
+			&step.Status, &result, &stepErr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workflow step: %w", err)
+		}
+
+		step.InputFrom = inputFrom.String
+		step.Result = result.String
+		step.Error = stepErr.String
+		workflow.Steps = append(workflow.Steps, step)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return workflow, nil
+}
+
+// PassWorkflowResult copies a finished step's result into the payload of every step reading from it
+func (s *Store) PassWorkflowResult(jobID, result string) error {
+	query := `
+		UPDATE jobs SET payload = $2, updated_at = $3
+		WHERE id IN (
+			SELECT consumer.job_id
+			FROM workflow_steps producer
+			JOIN workflow_steps consumer
+				ON consumer.workflow_id = producer.workflow_id AND consumer.input_from = producer.name
+			WHERE producer.job_id = $1
+		)
+	`
+
+	if _, err := s.db.Exec(query, jobID, payloadJSON(result), time.Now()); err != nil {
+		return fmt.Errorf("failed to pass workflow result: %w", err)
+	}
+
+	return nil
+}
+
+// FinishWorkflow stores the final status and output of a running workflow.
+// It returns false if the workflow had already finished.
+func (s *Store) FinishWorkflow(workflow *interfaces.Workflow) (bool, error) {
+	query := `
+		UPDATE workflows
+		SET status = $2, output = $3, error = $4, updated_at = $5, completed_at = $5
+		WHERE id = $1 AND status = 'running'
+	`
+
+	now := time.Now()
+	result, err := s.db.Exec(query,
+		workflow.ID, workflow.Status, nullString(workflow.Output), nullString(workflow.Error), now)
+	if err != nil {
+		return false, fmt.Errorf("failed to finish workflow: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	workflow.UpdatedAt = now
+	workflow.CompletedAt = &now
+	return true, nil
+}
@@ -2,13 +2,20 @@ package grpc
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log"
+	"strings"
 	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
 	"github.com/mtr002/Job-Queue/proto"
 )
 
@@ -38,17 +45,18 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
-func (c *Client) SubmitJob(jobType, payload string, maxAttempts int) (*interfaces.Job, error) {
+func (c *Client) SubmitJob(req jobs.JobRequest) (*interfaces.Job, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	resp, err := c.client.SubmitJob(ctx, &proto.SubmitJobRequest{
-		Type:        jobType,
-		Payload:     payload,
-		MaxAttempts: int32(maxAttempts),
-	})
+	return c.SubmitJobContext(ctx, req)
+}
+
+// SubmitJobContext is SubmitJob bounded by the caller's context instead of a fixed timeout
+func (c *Client) SubmitJobContext(ctx context.Context, req jobs.JobRequest) (*interfaces.Job, error) {
+	resp, err := c.client.SubmitJob(ctx, submitJobRequestToProto(req))
 	if err != nil {
-		return nil, err
+		return nil, submitError(err, req.Type)
 	}
 
 	createdAt, err := time.Parse(time.RFC3339, resp.CreatedAt)
@@ -57,23 +65,154 @@ func (c *Client) SubmitJob(jobType, payload string, maxAttempts int) (*interface
 		createdAt = time.Now()
 	}
 
+	queue := req.Queue
+	if queue == "" {
+		queue = interfaces.DefaultQueue
+	}
+
 	job := &interfaces.Job{
-		ID:          resp.JobId,
-		Type:        jobType,
-		Payload:     payload,
-		Status:      interfaces.JobStatus(resp.Status),
-		MaxAttempts: maxAttempts,
-		CreatedAt:   createdAt,
-		UpdatedAt:   createdAt,
+		ID:                  resp.JobId,
+		Type:                req.Type,
+		Payload:             req.Payload,
+		Status:              interfaces.JobStatus(resp.Status),
+		Queue:               queue,
+		OrderingKey:         req.OrderingKey,
+		ExpiresAt:           req.ExpiresAt,
+		MaxAttempts:         req.MaxAttempts,
+		DependsOn:           req.DependsOn,
+		DependencyPolicy:    req.DependencyPolicy,
+		CompensationType:    req.CompensationType,
+		CompensationPayload: req.CompensationPayload,
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
 	}
 
 	return job, nil
 }
 
+// submitError restores the job manager's sentinel for a rejected submission,
+// or the payload error listing the fields of a payload that broke its schema
+func submitError(err error, jobType string) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var sentinel error
+	switch st.Code() {
+	case codes.InvalidArgument:
+		payloadErr := &jobs.PayloadError{Type: jobType}
+		for _, detail := range st.Details() {
+			badRequest, ok := detail.(*errdetails.BadRequest)
+			if !ok {
+				continue
+			}
+			for _, violation := range badRequest.FieldViolations {
+				payloadErr.Fields = append(payloadErr.Fields, jobs.FieldError{
+					Field:   violation.Field,
+					Message: violation.Description,
+				})
+			}
+		}
+		if len(payloadErr.Fields) > 0 {
+			return payloadErr
+		}
+		sentinel = jobs.ErrInvalidJob
+	case codes.FailedPrecondition:
+		sentinel = jobs.ErrQueueDraining
+	case codes.AlreadyExists:
+		sentinel = jobs.ErrDuplicateJob
+	default:
+		return err
+	}
+	return fmt.Errorf("%w: %s", sentinel, strings.TrimPrefix(st.Message(), sentinel.Error()+": "))
+}
+
+// submitJobRequestToProto converts a job request into its wire form
+func submitJobRequestToProto(req jobs.JobRequest) *proto.SubmitJobRequest {
+	return &proto.SubmitJobRequest{
+		Type:                req.Type,
+		Payload:             req.Payload,
+		Queue:               req.Queue,
+		MaxAttempts:         int32(req.MaxAttempts),
+		DependsOn:           req.DependsOn,
+		DependencyPolicy:    string(req.DependencyPolicy),
+		CompensationType:    req.CompensationType,
+		CompensationPayload: req.CompensationPayload,
+		UniqueKey:           req.UniqueKey,
+		UniqueScope:         string(req.UniqueScope),
+		UniqueWindow:        req.UniqueWindow,
+		OnDuplicate:         string(req.OnDuplicate),
+		OrderingKey:         req.OrderingKey,
+		ExpiresAt:           formatTimestamp(req.ExpiresAt),
+		Priority:            optionalInt32(req.Priority),
+	}
+}
+
+// optionalInt32 converts an optional integer into its wire form, keeping it unset when it is nil
+func optionalInt32(n *int) *int32 {
+	if n == nil {
+		return nil
+	}
+	v := int32(*n)
+	return &v
+}
+
+// formatTimestamp renders an optional timestamp as RFC3339, or empty when unset
+func formatTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// SubmitJobs streams many jobs to the worker service and returns per-item results
+func (c *Client) SubmitJobs(reqs []jobs.JobRequest) ([]jobs.SubmitResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	stream, err := c.client.SubmitJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, req := range reqs {
+		err := stream.Send(submitJobRequestToProto(req))
+		if err == io.EOF {
+			// The server ended the stream early; CloseAndRecv reports why
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]jobs.SubmitResult, len(resp.Results))
+	for i, r := range resp.Results {
+		results[i] = jobs.SubmitResult{
+			Index: int(r.Index),
+			ID:    r.JobId,
+			Error: r.Error,
+		}
+	}
+
+	return results, nil
+}
+
 func (c *Client) GetJobStatus(jobID string) (*interfaces.Job, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	return c.GetJobStatusContext(ctx, jobID)
+}
+
+// GetJobStatusContext is GetJobStatus bounded by the caller's context instead of a fixed timeout
+func (c *Client) GetJobStatusContext(ctx context.Context, jobID string) (*interfaces.Job, error) {
 	resp, err := c.client.GetJobStatus(ctx, &proto.GetJobRequest{
 		JobId: jobID,
 	})
@@ -91,18 +230,74 @@ func (c *Client) GetJobStatus(jobID string) (*interfaces.Job, error) {
 		updatedAt = time.Now()
 	}
 
+	var expiresAt *time.Time
+	if t, err := time.Parse(time.RFC3339, resp.ExpiresAt); err == nil {
+		expiresAt = &t
+	}
+
 	job := &interfaces.Job{
 		ID:          resp.JobId,
 		Type:        resp.Type,
 		Payload:     resp.Payload,
 		Status:      interfaces.JobStatus(resp.Status),
+		Queue:       resp.Queue,
 		Result:      resp.Result,
+		UniqueKey:   resp.UniqueKey,
+		OrderingKey: resp.OrderingKey,
 		Error:       resp.Error,
 		Attempts:    int(resp.Attempts),
 		MaxAttempts: int(resp.MaxAttempts),
+		Priority:    int(resp.Priority),
+		ExpiresAt:   expiresAt,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
+
+		CompensationStatus: interfaces.CompensationStatus(resp.CompensationStatus),
+		CompensationJobID:  resp.CompensationJobId,
+
+		Progress:        int(resp.Progress),
+		ProgressMessage: resp.ProgressMessage,
+		Checkpoint:      resp.Checkpoint,
 	}
 
 	return job, nil
 }
+
+// StreamJobLogs passes the log lines of a job after afterID to fn. With follow it keeps
+// waiting for new lines until the job finishes or ctx is done.
+func (c *Client) StreamJobLogs(ctx context.Context, jobID string, afterID int64, follow bool, fn func(entry *interfaces.JobLog) error) error {
+	stream, err := c.client.StreamJobLogs(ctx, &proto.StreamJobLogsRequest{
+		JobId:   jobID,
+		AfterId: afterID,
+		Follow:  follow,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		createdAt, err := time.Parse(time.RFC3339, resp.CreatedAt)
+		if err != nil {
+			createdAt = time.Now()
+		}
+
+		if err := fn(&interfaces.JobLog{
+			ID:        resp.Id,
+			JobID:     resp.JobId,
+			Attempt:   int(resp.Attempt),
+			Level:     resp.Level,
+			Message:   resp.Message,
+			CreatedAt: createdAt,
+		}); err != nil {
+			return err
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -15,21 +17,145 @@ const (
 	StatusFailed          JobStatus = "failed"
 	StatusRetrying        JobStatus = "retrying"
 	StatusPermanentFailed JobStatus = "permanent_failed"
+	StatusBlocked         JobStatus = "blocked"
+	StatusSkipped         JobStatus = "skipped"
+	StatusExpired         JobStatus = "expired"
+	StatusCancelled       JobStatus = "cancelled"
+)
+
+// IsTerminal returns true if a job in this status will not change anymore
+func (s JobStatus) IsTerminal() bool {
+	switch s {
+	case StatusCompleted, StatusPermanentFailed, StatusSkipped, StatusExpired, StatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// DefaultQueue is the queue jobs are submitted to when none is given
+const DefaultQueue = "default"
+
+// UniqueScope decides how long a job's unique key keeps other jobs with the same key out
+type UniqueScope string
+
+const (
+	// UniquePending holds the key while the job waits to run for the first time
+	UniquePending UniqueScope = "pending"
+	// UniquePendingOrRunning holds the key until the job finishes
+	UniquePendingOrRunning UniqueScope = "pending_or_running"
+	// UniqueWindow holds the key for a fixed time after submission, whatever the job's status
+	UniqueWindow UniqueScope = "window"
+)
+
+// IsValid returns true for the known unique scopes
+func (s UniqueScope) IsValid() bool {
+	switch s {
+	case UniquePending, UniquePendingOrRunning, UniqueWindow:
+		return true
+	}
+	return false
+}
+
+// DuplicateJobError is returned when a job's unique key is already held by another job
+type DuplicateJobError struct {
+	Key        string
+	ExistingID string
+}
+
+func (e *DuplicateJobError) Error() string {
+	return fmt.Sprintf("unique key %q is held by job %s", e.Key, e.ExistingID)
+}
+
+// DependencyPolicy decides what happens to a blocked job when a job it depends on does not succeed
+type DependencyPolicy string
+
+const (
+	DependencyPolicyFail      DependencyPolicy = "fail"
+	DependencyPolicySkip      DependencyPolicy = "skip"
+	DependencyPolicyRunAnyway DependencyPolicy = "run_anyway"
+)
+
+// IsValid returns true for the known dependency policies
+func (p DependencyPolicy) IsValid() bool {
+	switch p {
+	case DependencyPolicyFail, DependencyPolicySkip, DependencyPolicyRunAnyway:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResolveDependencyStatus decides the status of a job from the statuses of the jobs it depends on.
+// It returns StatusBlocked while the job still has to wait, plus an error message for failed jobs.
+func ResolveDependencyStatus(policy DependencyPolicy, parents map[string]JobStatus) (JobStatus, string) {
+	waiting := false
+	for id, status := range parents {
+		switch {
+		case status == StatusCompleted:
+		case status.IsTerminal():
+			switch policy {
+			case DependencyPolicySkip:
+				return StatusSkipped, fmt.Sprintf("dependency %s did not succeed", id)
+			case DependencyPolicyRunAnyway:
+			default:
+				return StatusPermanentFailed, fmt.Sprintf("dependency %s did not succeed", id)
+			}
+		default:
+			waiting = true
+		}
+	}
+
+	if waiting {
+		return StatusBlocked, ""
+	}
+	return StatusPending, ""
+}
+
+// CompensationStatus tracks the rollback of a completed job
+type CompensationStatus string
+
+const (
+	CompensationPending   CompensationStatus = "pending"
+	CompensationCompleted CompensationStatus = "completed"
+	CompensationFailed    CompensationStatus = "failed"
 )
 
 // Job represents a job in the queue
 type Job struct {
-	ID          string     `json:"id"`
-	Type        string     `json:"type"`
-	Payload     string     `json:"payload"`
-	Status      JobStatus  `json:"status"`
-	Result      string     `json:"result,omitempty"`
-	Error       string     `json:"error,omitempty"`
-	Attempts    int        `json:"attempts"`
-	MaxAttempts int        `json:"max_attempts"`
-	RetryAfter  *time.Time `json:"retry_after,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID              string    `json:"id"`
+	Type            string    `json:"type"`
+	Payload         string    `json:"payload"`
+	Status          JobStatus `json:"status"`
+	Queue           string    `json:"queue"`
+	OrderingKey     string    `json:"ordering_key,omitempty"`
+	Result          string    `json:"result,omitempty"`
+	Error           string    `json:"error,omitempty"`
+	Progress        int       `json:"progress"`
+	ProgressMessage string    `json:"progress_message,omitempty"`
+	Checkpoint      string    `json:"checkpoint,omitempty"`
+	ClaimedBy       string    `json:"claimed_by,omitempty"`
+	// ClaimToken identifies the claim the job is running under; outcomes are only stored while it is current
+	ClaimToken          string             `json:"-"`
+	Attempts            int                `json:"attempts"`
+	MaxAttempts         int                `json:"max_attempts"`
+	Priority            int                `json:"priority"`
+	RetryAfter          *time.Time         `json:"retry_after,omitempty"`
+	ExpiresAt           *time.Time         `json:"expires_at,omitempty"`
+	BatchID             string             `json:"batch_id,omitempty"`
+	WorkflowID          string             `json:"workflow_id,omitempty"`
+	CompensationType    string             `json:"compensation_type,omitempty"`
+	CompensationPayload string             `json:"compensation_payload,omitempty"`
+	CompensationStatus  CompensationStatus `json:"compensation_status,omitempty"`
+	CompensationJobID   string             `json:"compensation_job_id,omitempty"`
+	CompensatesJobID    string             `json:"compensates_job_id,omitempty"`
+	DependsOn           []string           `json:"depends_on,omitempty"`
+	DependencyPolicy    DependencyPolicy   `json:"dependency_policy,omitempty"`
+	UniqueKey           string             `json:"unique_key,omitempty"`
+	UniqueScope         UniqueScope        `json:"unique_scope,omitempty"`
+	UniqueUntil         *time.Time         `json:"unique_until,omitempty"`
+	CreatedAt           time.Time          `json:"created_at"`
+	UpdatedAt           time.Time          `json:"updated_at"`
 }
 
 // String returns a string representation of the job
@@ -50,6 +176,11 @@ func (j *Job) IncrementAttempts() {
 
 // SetRetryAfter sets the retry after time with exponential backoff
 func (j *Job) SetRetryAfter(baseDelaySec int) {
+	j.SetRetryAfterWithin(baseDelaySec, 5*time.Minute)
+}
+
+// SetRetryAfterWithin sets the retry after time with exponential backoff, never waiting longer than maxDelay
+func (j *Job) SetRetryAfterWithin(baseDelaySec int, maxDelay time.Duration) {
 	if baseDelaySec <= 0 {
 		baseDelaySec = 1
 	}
@@ -58,8 +189,6 @@ func (j *Job) SetRetryAfter(baseDelaySec int) {
 	// For attempts 1,2,3: delays are 1s, 2s, 4s (if baseDelay=1)
 	backoffDelay := time.Duration(1<<(j.Attempts-1)) * time.Duration(baseDelaySec) * time.Second
 
-	// Cap the maximum delay at 5 minutes
-	maxDelay := 5 * time.Minute
 	if backoffDelay > maxDelay {
 		backoffDelay = maxDelay
 	}
@@ -68,6 +197,11 @@ func (j *Job) SetRetryAfter(baseDelaySec int) {
 	j.RetryAfter = &retryTime
 }
 
+// ExpiresBefore returns true if the job has a deadline earlier than t
+func (j *Job) ExpiresBefore(t time.Time) bool {
+	return j.ExpiresAt != nil && j.ExpiresAt.Before(t)
+}
+
 // IsReadyForRetry returns true if the job is ready to be retried
 func (j *Job) IsReadyForRetry() bool {
 	if j.Status != StatusRetrying || j.RetryAfter == nil {
@@ -76,12 +210,361 @@ func (j *Job) IsReadyForRetry() bool {
 	return time.Now().After(*j.RetryAfter)
 }
 
+// JobType is a registered job type: the JSON Schema its payloads must match, if any,
+// and the defaults applied to jobs of the type
+type JobType struct {
+	Name               string          `json:"name"`
+	Description        string          `json:"description,omitempty"`
+	PayloadSchema      json.RawMessage `json:"payload_schema,omitempty"`
+	DefaultQueue       string          `json:"default_queue,omitempty"`
+	DefaultMaxAttempts int             `json:"default_max_attempts,omitempty"`
+	RetryPolicy        *RetryPolicy    `json:"retry_policy,omitempty"`
+	TimeoutSeconds     int             `json:"timeout_seconds,omitempty"`
+	Priority           int             `json:"priority"`
+	OwnerTeam          string          `json:"owner_team,omitempty"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+}
+
+// RetryPolicy sets the exponential backoff between the attempts of a job type
+type RetryPolicy struct {
+	BaseDelaySeconds int `json:"base_delay_seconds"`
+	MaxDelaySeconds  int `json:"max_delay_seconds,omitempty"`
+}
+
+// JobLog is a line a handler logged while processing a job
+type JobLog struct {
+	ID        int64     `json:"id"`
+	JobID     string    `json:"job_id"`
+	Attempt   int       `json:"attempt"`
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BatchCallback describes the job enqueued when a batch finishes
+type BatchCallback struct {
+	Type    string `json:"type"`
+	Payload string `json:"payload,omitempty"`
+	// JobID is the callback job, stored blocked with the batch until the batch finishes with this outcome
+	JobID string `json:"job_id,omitempty"`
+}
+
+// Batch groups related jobs and tracks their combined progress
+type Batch struct {
+	ID            string         `json:"id"`
+	Total         int            `json:"total"`
+	Succeeded     int            `json:"succeeded"`
+	Failed        int            `json:"failed"`
+	OnSuccess     *BatchCallback `json:"on_success,omitempty"`
+	OnFailure     *BatchCallback `json:"on_failure,omitempty"`
+	CallbackJobID string         `json:"callback_job_id,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	CompletedAt   *time.Time     `json:"completed_at,omitempty"`
+}
+
+// Pending returns the number of member jobs that have not reached a terminal status
+func (b *Batch) Pending() int {
+	return b.Total - b.Succeeded - b.Failed
+}
+
+// IsFinished returns true once every member job reached a terminal status
+func (b *Batch) IsFinished() bool {
+	return b.Pending() <= 0
+}
+
+// Callback returns the callback matching the batch outcome, if any
+func (b *Batch) Callback() *BatchCallback {
+	if b.Failed > 0 {
+		return b.OnFailure
+	}
+	return b.OnSuccess
+}
+
+// Summary is the default callback payload describing the batch
+func (b *Batch) Summary() string {
+	summary, _ := json.Marshal(map[string]interface{}{
+		"batch_id":  b.ID,
+		"total":     b.Total,
+		"succeeded": b.Succeeded,
+		"failed":    b.Failed,
+	})
+	return string(summary)
+}
+
+// WorkflowStatus represents the overall state of a workflow
+type WorkflowStatus string
+
+const (
+	WorkflowRunning   WorkflowStatus = "running"
+	WorkflowCompleted WorkflowStatus = "completed"
+	WorkflowFailed    WorkflowStatus = "failed"
+)
+
+// WorkflowStep is one step of a workflow together with the state of its job
+type WorkflowStep struct {
+	Name      string    `json:"name"`
+	JobID     string    `json:"job_id"`
+	Type      string    `json:"type"`
+	DependsOn []string  `json:"depends_on,omitempty"`
+	InputFrom string    `json:"input_from,omitempty"`
+	Status    JobStatus `json:"status"`
+	Result    string    `json:"result,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Workflow is a named set of dependent jobs whose results feed each other
+type Workflow struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Status      WorkflowStatus  `json:"status"`
+	OutputStep  string          `json:"output_step"`
+	Output      string          `json:"output,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	Steps       []*WorkflowStep `json:"steps"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+// QueueControlKind says whether a queue state or rate limit applies to a queue or to a job type
+type QueueControlKind string
+
+const (
+	QueueControlQueue QueueControlKind = "queue"
+	QueueControlType  QueueControlKind = "type"
+)
+
+// QueueControlState is the runtime state an operator put a queue or job type in
+type QueueControlState string
+
+const (
+	// QueueActive accepts and processes jobs as usual
+	QueueActive QueueControlState = "active"
+	// QueuePaused accepts jobs but does not hand them to workers
+	QueuePaused QueueControlState = "paused"
+	// QueueDraining processes the jobs it already has but rejects new ones
+	QueueDraining QueueControlState = "draining"
+)
+
+// QueueState records that a queue or job type is paused or draining
+type QueueState struct {
+	Kind      QueueControlKind  `json:"kind"`
+	Name      string            `json:"name"`
+	State     QueueControlState `json:"state"`
+	Reason    string            `json:"reason,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// Matches returns true if the state applies to the given job
+func (s *QueueState) Matches(job *Job) bool {
+	switch s.Kind {
+	case QueueControlQueue:
+		return s.Name == job.Queue
+	case QueueControlType:
+		return s.Name == job.Type
+	}
+	return false
+}
+
+// TypeLimit caps how many jobs of one type may run at once across all workers
+type TypeLimit struct {
+	Type           string    `json:"type"`
+	MaxConcurrency int       `json:"max_concurrency"`
+	Running        int       `json:"running"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// RateLimitPeriods maps the supported rate limit periods to their length
+var RateLimitPeriods = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+}
+
+// RateLimit is a token bucket allowing Limit jobs of a queue or type to start per Period
+type RateLimit struct {
+	Kind   QueueControlKind `json:"kind"`
+	Name   string           `json:"name"`
+	Limit  int              `json:"limit"`
+	Period string           `json:"period"`
+	// Burst is the bucket size, the most jobs that may start at once after an idle spell
+	Burst int `json:"burst"`
+	// Tokens is how many more jobs may start right now
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ClaimFilter narrows down which jobs GetPendingJob may claim
+type ClaimFilter struct {
+	// Queues restricts claims to these queues; any queue if empty
+	Queues        []string
+	ExcludeTypes  []string
+	ExcludeQueues []string
+	// WorkerID is recorded on the claimed job as the worker process running it; a claim needs one
+	WorkerID string
+}
+
+// Claim is the outcome of GetPendingJob
+type Claim struct {
+	// Job is the claimed job, nil if none could be claimed
+	Job *Job
+	// RateLimited are the queues and types whose empty rate limit bucket refused a job,
+	// so the claim skipped them; only Kind and Name are set
+	RateLimited []*RateLimit
+}
+
+// JobFilter narrows down which jobs ListJobs returns; empty fields match every job
+type JobFilter struct {
+	Status JobStatus
+	Type   string
+	Queue  string
+	// Limit caps how many jobs are returned, newest first; no limit if zero
+	Limit int
+}
+
+// JobCount is the number of jobs in one queue with one status
+type JobCount struct {
+	Queue  string    `json:"queue"`
+	Status JobStatus `json:"status"`
+	Count  int       `json:"count"`
+}
+
+// QueueDepth is the backlog of jobs ready to run in a set of queues
+type QueueDepth struct {
+	// Ready counts the pending jobs and the retrying jobs whose backoff has passed that a worker could claim now
+	Ready int
+	// OldestReadyAt is when the job waiting longest became ready; nil if no job is ready
+	OldestReadyAt *time.Time
+}
+
+// ErrDependencyNotFound is returned when a job depends on a job that does not exist
+var ErrDependencyNotFound = errors.New("dependency not found")
+
+// ErrClaimLost is returned when storing the outcome of a job that was released from the claim running it,
+// such as after its worker was declared dead, since the job belongs to whoever claims it next
+var ErrClaimLost = errors.New("job is no longer claimed by this run")
+
+// Expiry is a job that passed its deadline before it could start, with what expiring it resolved
+type Expiry struct {
+	// Job is the job as stored, expired
+	Job *Job
+	// Resolved are the dependents whose status changed with the expiry
+	Resolved []*Job
+	// FinishedBatches are the batches the expiry finished
+	FinishedBatches []*Batch
+}
+
+// Release is what became of a processing job handed back to the queue before it finished
+type Release struct {
+	// Job is the job as stored, pending again unless it was cancelled
+	Job *Job
+	// Cancelled is set when another job took the released job's unique key while it ran,
+	// so it was cancelled as a duplicate rather than wait next to that job
+	Cancelled bool
+	// Resolved are the dependents whose status changed with the cancellation
+	Resolved []*Job
+	// FinishedBatches are the batches the cancellation finished
+	FinishedBatches []*Batch
+}
+
+// WorkerStatus is the lifecycle state of a registered worker service process
+type WorkerStatus string
+
+const (
+	// WorkerActive is sending heartbeats
+	WorkerActive WorkerStatus = "active"
+	// WorkerStopped shut down cleanly
+	WorkerStopped WorkerStatus = "stopped"
+	// WorkerDead missed its heartbeats, so the jobs it was running were released back to the queue
+	WorkerDead WorkerStatus = "dead"
+)
+
+// Worker is a worker service process as recorded in the worker registry
+type Worker struct {
+	ID       string `json:"id"`
+	Hostname string `json:"hostname"`
+	PID      int    `json:"pid"`
+	Version  string `json:"version"`
+	// Queues the process claims jobs from; every queue if empty
+	Queues      []string     `json:"queues"`
+	Concurrency int          `json:"concurrency"`
+	Status      WorkerStatus `json:"status"`
+	// Running holds the job each of the process's workers was running at its last heartbeat
+	Running         []*WorkerJob `json:"running"`
+	StartedAt       time.Time    `json:"started_at"`
+	LastHeartbeatAt time.Time    `json:"last_heartbeat_at"`
+	// DeadAfter is when the process is declared dead if no heartbeat arrives before then
+	DeadAfter time.Time  `json:"dead_after"`
+	StoppedAt *time.Time `json:"stopped_at,omitempty"`
+}
+
+// WorkerJob is a job one worker goroutine of a worker service process is running
+type WorkerJob struct {
+	// Pool lists the queues of the goroutine's pool, "*" for every queue
+	Pool      string    `json:"pool"`
+	Worker    int       `json:"worker"`
+	JobID     string    `json:"job_id"`
+	Type      string    `json:"type"`
+	StartedAt time.Time `json:"started_at"`
+}
+
 // JobStore interface defines the database operations needed by the manager
 type JobStore interface {
 	CreateJob(job *Job) error
+	CreateJobs(jobs []*Job) error
 	GetJob(id string) (*Job, error)
 	UpdateJob(job *Job) error
-	GetPendingJob() (*Job, error)
+	UpdateJobProgress(job *Job) error
+	ReleaseJob(id, claimToken string) (*Release, error)
+	UpdateJobAndResolveDependents(job *Job) ([]*Job, []*Batch, error)
+	ExpireJobs(limit int) ([]*Expiry, error)
+	GetCompensable(failedJobID string) ([]*Job, error)
+	ClaimCompensations(failedJobID string, compensations []*Job) ([]*Job, error)
+	SetCompensationStatus(jobID string, status CompensationStatus) error
+	GetCompensations(status CompensationStatus) ([]*Job, error)
+	GetPendingJob(filter ClaimFilter) (*Claim, error)
 	GetAllJobs() ([]*Job, error)
 	DeleteJob(id string) error
+
+	ListJobs(filter JobFilter) ([]*Job, error)
+	CountJobs() ([]*JobCount, error)
+	GetQueueDepth(queues []string) (*QueueDepth, error)
+	CancelJob(id string) (*Job, []*Job, []*Batch, error)
+	RetryJob(id string) (*Job, error)
+
+	CreateBatch(batch *Batch, jobs, callbacks []*Job) error
+	GetBatch(id string) (*Batch, error)
+	SetBatchCallbackJob(batchID, jobID string) error
+
+	CreateWorkflow(workflow *Workflow, jobs []*Job) error
+	GetWorkflow(id string) (*Workflow, error)
+	PassWorkflowResult(jobID, result string) error
+	FinishWorkflow(workflow *Workflow) (bool, error)
+
+	SetQueueState(state *QueueState) error
+	GetQueueStates() ([]*QueueState, error)
+
+	SetTypeLimit(limit *TypeLimit) error
+	GetTypeLimits() ([]*TypeLimit, error)
+
+	SetRateLimit(limit *RateLimit) error
+	DeleteRateLimit(kind QueueControlKind, name string) error
+	GetRateLimits() ([]*RateLimit, error)
+
+	AddJobLog(entry *JobLog) error
+	GetJobLogs(jobID string, afterID int64, limit int) ([]*JobLog, error)
+
+	SetJobType(jobType *JobType) error
+	GetJobType(name string) (*JobType, error)
+	GetJobTypes() ([]*JobType, error)
+	DeleteJobType(name string) error
+
+	RegisterWorker(worker *Worker, timeout time.Duration) error
+	HeartbeatWorker(worker *Worker, timeout time.Duration) (bool, error)
+	StopWorker(id string) error
+	ListWorkers() ([]*Worker, error)
+	MarkDeadWorkers() ([]*Worker, error)
+	ReleaseWorkerJobs(workerID string) ([]*Release, error)
+	DeleteWorkers(stoppedBefore time.Time) (int64, error)
 }
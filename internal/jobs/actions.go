@@ -0,0 +1,136 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/logger"
+	"github.com/mtr002/Job-Queue/internal/metrics"
+)
+
+var (
+	// ErrJobNotCancellable is returned when cancelling a job that is running or already finished
+	ErrJobNotCancellable = errors.New("job cannot be cancelled")
+	// ErrJobNotRetryable is returned when retrying a job that has not failed, expired or been cancelled
+	ErrJobNotRetryable = errors.New("job cannot be retried")
+)
+
+// Stats summarizes how many jobs are in each status, overall and per queue
+type Stats struct {
+	Total    int                                     `json:"total"`
+	ByStatus map[interfaces.JobStatus]int            `json:"by_status"`
+	ByQueue  map[string]map[interfaces.JobStatus]int `json:"by_queue"`
+}
+
+// ListJobs retrieves the jobs matching the filter, newest first
+func (m *Manager) ListJobs(filter interfaces.JobFilter) ([]*interfaces.Job, error) {
+	return m.store.ListJobs(filter)
+}
+
+// CancelJob stops a job that has not started running from ever running.
+// Blocked jobs depending on it are resolved as they would be for a failed dependency.
+func (m *Manager) CancelJob(id string) (*interfaces.Job, error) {
+	job, resolved, finished, err := m.store.CancelJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		current, err := m.store.GetJob(id)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: job is %s", ErrJobNotCancellable, current.Status)
+	}
+
+	metrics.JobsCancelledTotal.Inc()
+	logger.WithJobID(job.ID).Info().Msg("Job cancelled")
+
+	m.onJobTerminal(job)
+	m.onDependentsResolved(job, resolved)
+	m.onBatchesFinished(finished)
+
+	return job, nil
+}
+
+// RetryJob sends a job that failed, expired or was cancelled back to pending to start over, without its progress or checkpoint
+func (m *Manager) RetryJob(id string) (*interfaces.Job, error) {
+	job, err := m.store.RetryJob(id)
+	if err != nil {
+		var dup *interfaces.DuplicateJobError
+		if errors.As(err, &dup) {
+			return nil, fmt.Errorf("%w: %v", ErrJobNotRetryable, dup)
+		}
+		return nil, err
+	}
+	if job == nil {
+		current, err := m.store.GetJob(id)
+		if err != nil {
+			return nil, err
+		}
+		if current.BatchID != "" || current.WorkflowID != "" {
+			return nil, fmt.Errorf("%w: jobs in a batch or workflow cannot be retried", ErrJobNotRetryable)
+		}
+		return nil, fmt.Errorf("%w: job is %s", ErrJobNotRetryable, current.Status)
+	}
+
+	metrics.JobsManuallyRetriedTotal.Inc()
+	logger.WithJobID(job.ID).Info().Msg("Job sent back to pending for retry")
+
+	return job, nil
+}
+
+// ReplayDeadLetters retries every permanently failed job of the given type and queue, or of all of them if empty.
+// Jobs that cannot be retried, such as members of a batch or workflow, are skipped.
+func (m *Manager) ReplayDeadLetters(jobType, queue string) ([]*interfaces.Job, error) {
+	dead, err := m.store.ListJobs(interfaces.JobFilter{
+		Status: interfaces.StatusPermanentFailed,
+		Type:   jobType,
+		Queue:  queue,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var replayed []*interfaces.Job
+	for _, job := range dead {
+		if job.BatchID != "" || job.WorkflowID != "" {
+			continue
+		}
+
+		retried, err := m.RetryJob(job.ID)
+		if errors.Is(err, ErrJobNotRetryable) {
+			logger.WithJobID(job.ID).Warn().Err(err).Msg("Skipping dead letter")
+			continue
+		}
+		if err != nil {
+			return replayed, fmt.Errorf("failed to replay job %s: %w", job.ID, err)
+		}
+		replayed = append(replayed, retried)
+	}
+
+	return replayed, nil
+}
+
+// Stats counts the jobs in each status, overall and per queue
+func (m *Manager) Stats() (*Stats, error) {
+	counts, err := m.store.CountJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{
+		ByStatus: make(map[interfaces.JobStatus]int),
+		ByQueue:  make(map[string]map[interfaces.JobStatus]int),
+	}
+	for _, count := range counts {
+		stats.Total += count.Count
+		stats.ByStatus[count.Status] += count.Count
+		if stats.ByQueue[count.Queue] == nil {
+			stats.ByQueue[count.Queue] = make(map[interfaces.JobStatus]int)
+		}
+		stats.ByQueue[count.Queue][count.Status] += count.Count
+	}
+
+	return stats, nil
+}
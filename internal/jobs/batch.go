@@ -0,0 +1,168 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/logger"
+	"github.com/mtr002/Job-Queue/internal/metrics"
+)
+
+// ErrInvalidBatch is returned when a batch or one of its members fails validation
+var ErrInvalidBatch = errors.New("invalid batch")
+
+// SubmitBatch creates a batch and all of its member jobs atomically.
+// If any member is invalid nothing is stored and the per-item errors are returned with ErrInvalidBatch.
+func (m *Manager) SubmitBatch(reqs []JobRequest, onSuccess, onFailure *interfaces.BatchCallback) (*interfaces.Batch, []SubmitResult, error) {
+	if len(reqs) == 0 {
+		return nil, nil, fmt.Errorf("%w: batch must contain at least one job", ErrInvalidBatch)
+	}
+	if onSuccess != nil && onSuccess.Type == "" {
+		return nil, nil, fmt.Errorf("%w: on_success callback type cannot be empty", ErrInvalidBatch)
+	}
+	if onFailure != nil && onFailure.Type == "" {
+		return nil, nil, fmt.Errorf("%w: on_failure callback type cannot be empty", ErrInvalidBatch)
+	}
+
+	batch := &interfaces.Batch{
+		ID:        uuid.New().String(),
+		Total:     len(reqs),
+		OnSuccess: onSuccess,
+		OnFailure: onFailure,
+		CreatedAt: time.Now(),
+	}
+
+	states, err := m.store.GetQueueStates()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := make([]SubmitResult, len(reqs))
+	members := make([]*interfaces.Job, 0, len(reqs))
+	invalid := false
+	for i, req := range reqs {
+		results[i].Index = i
+		if len(req.DependsOn) > 0 {
+			results[i].Error = "depends_on is not supported for batch members"
+			invalid = true
+			continue
+		}
+		if req.UniqueKey != "" {
+			results[i].Error = "unique_key is not supported for batch members"
+			invalid = true
+			continue
+		}
+		job, err := m.newJob(req)
+		if err == nil {
+			err = checkDraining(states, job)
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			invalid = true
+			continue
+		}
+		job.BatchID = batch.ID
+		members = append(members, job)
+		results[i].ID = job.ID
+	}
+	if invalid {
+		for i := range results {
+			results[i].ID = ""
+		}
+		return nil, results, fmt.Errorf("%w: batch contains invalid jobs", ErrInvalidBatch)
+	}
+
+	var callbacks []*interfaces.Job
+	for _, cb := range []struct {
+		name     string
+		callback *interfaces.BatchCallback
+	}{{"on_success", onSuccess}, {"on_failure", onFailure}} {
+		if cb.callback == nil {
+			continue
+		}
+		callback, err := m.newCallbackJob(batch, cb.callback)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s callback: %v", ErrInvalidBatch, cb.name, err)
+		}
+		callbacks = append(callbacks, callback)
+	}
+
+	if err := m.store.CreateBatch(batch, members, callbacks); err != nil {
+		return nil, nil, fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	metrics.JobsSubmittedTotal.Add(float64(len(members)))
+	logger.Logger.Info().Str("batch_id", batch.ID).Int("total", batch.Total).Msg("Batch submitted successfully")
+	return batch, results, nil
+}
+
+// GetBatch retrieves a batch and its progress by ID
+func (m *Manager) GetBatch(id string) (*interfaces.Batch, error) {
+	return m.store.GetBatch(id)
+}
+
+// newCallbackJob validates a batch callback and builds its job, stored blocked with the batch.
+// The transaction finishing the batch releases the job if the batch ends the way it is meant for.
+func (m *Manager) newCallbackJob(batch *interfaces.Batch, cb *interfaces.BatchCallback) (*interfaces.Job, error) {
+	// Without a payload of its own the callback gets the batch summary, whose shape is checked with the counts at zero
+	payload := cb.Payload
+	if payload == "" {
+		payload = batch.Summary()
+	}
+
+	job, err := m.newJob(JobRequest{Type: cb.Type, Payload: payload})
+	if err != nil {
+		return nil, err
+	}
+	job.Status = interfaces.StatusBlocked
+	cb.JobID = job.ID
+	return job, nil
+}
+
+// onBatchesFinished logs each batch whose last member just reached a terminal status
+func (m *Manager) onBatchesFinished(batches []*interfaces.Batch) {
+	for _, batch := range batches {
+		m.finishBatch(batch)
+	}
+}
+
+// finishBatch logs a batch whose members have all reached a terminal status. Its callback job was released
+// by the store when the batch finished, except for batches stored before their callback jobs were, whose
+// callback is enqueued here.
+func (m *Manager) finishBatch(batch *interfaces.Batch) {
+	log := logger.Logger
+
+	log.Info().
+		Str("batch_id", batch.ID).
+		Int("succeeded", batch.Succeeded).
+		Int("failed", batch.Failed).
+		Msg("Batch finished")
+
+	cb := batch.Callback()
+	if cb == nil {
+		return
+	}
+	if cb.JobID != "" {
+		metrics.JobsSubmittedTotal.Inc()
+		log.Info().Str("batch_id", batch.ID).Str("job_id", cb.JobID).Msg("Batch callback enqueued")
+		return
+	}
+
+	payload := cb.Payload
+	if payload == "" {
+		payload = batch.Summary()
+	}
+
+	callback, err := m.submitFollowUp(JobRequest{Type: cb.Type, Payload: payload})
+	if err != nil {
+		log.Error().Err(err).Str("batch_id", batch.ID).Msg("Failed to enqueue batch callback")
+		return
+	}
+
+	if err := m.store.SetBatchCallbackJob(batch.ID, callback.ID); err != nil {
+		log.Error().Err(err).Str("batch_id", batch.ID).Msg("Failed to record batch callback job")
+	}
+}
@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"encoding/json"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/logger"
+	"github.com/mtr002/Job-Queue/internal/metrics"
+)
+
+// GetCompensations lists jobs whose rollback was triggered, optionally filtered by its status
+func (m *Manager) GetCompensations(status interfaces.CompensationStatus) ([]*interfaces.Job, error) {
+	return m.store.GetCompensations(status)
+}
+
+// compensate enqueues rollback jobs for the completed ancestors of a permanently failed job.
+// The rollbacks are chained newest first, and each one runs even if the previous rollback failed.
+func (m *Manager) compensate(failed *interfaces.Job) {
+	log := logger.WithJobID(failed.ID)
+
+	originals, err := m.store.GetCompensable(failed.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to find compensations")
+		return
+	}
+	if len(originals) == 0 {
+		return
+	}
+
+	// Ancestors whose rollback cannot be built are left out and recorded as failed by the claim
+	compensations := make([]*interfaces.Job, 0, len(originals))
+	for _, original := range originals {
+		job, err := m.newJob(JobRequest{
+			Type:             original.CompensationType,
+			Payload:          compensationPayload(original),
+			Queue:            original.Queue,
+			DependencyPolicy: interfaces.DependencyPolicyRunAnyway,
+			compensatesJobID: original.ID,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("compensates_job_id", original.ID).Msg("Failed to build compensation")
+			continue
+		}
+		compensations = append(compensations, job)
+	}
+
+	created, err := m.store.ClaimCompensations(failed.ID, compensations)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to enqueue compensations")
+		return
+	}
+
+	metrics.JobsSubmittedTotal.Add(float64(len(created)))
+	if len(created) > 0 {
+		log.Info().Int("compensations", len(created)).Msg("Compensations enqueued")
+	}
+}
+
+// recordCompensationOutcome stores whether the rollback performed by job succeeded
+func (m *Manager) recordCompensationOutcome(job *interfaces.Job) {
+	status := interfaces.CompensationCompleted
+	if job.Status != interfaces.StatusCompleted {
+		status = interfaces.CompensationFailed
+	}
+
+	if err := m.store.SetCompensationStatus(job.CompensatesJobID, status); err != nil {
+		log := logger.WithJobID(job.ID)
+		log.Error().Err(err).Str("compensates_job_id", job.CompensatesJobID).Msg("Failed to record compensation status")
+	}
+}
+
+// compensationPayload is the declared compensation payload or, by default,
+// a description of the job being rolled back
+func compensationPayload(job *interfaces.Job) string {
+	if job.CompensationPayload != "" {
+		return job.CompensationPayload
+	}
+
+	payload, _ := json.Marshal(map[string]string{
+		"job_id":  job.ID,
+		"type":    job.Type,
+		"payload": job.Payload,
+		"result":  job.Result,
+	})
+	return string(payload)
+}
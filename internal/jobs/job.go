@@ -1 +1,56 @@
 package jobs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+// ErrDuplicateJob is returned when a job's unique key is held by another job and the request asked for a conflict
+var ErrDuplicateJob = errors.New("duplicate job")
+
+// ErrInvalidJob is returned when a job request fails validation, such as naming a dependency that does not exist
+var ErrInvalidJob = errors.New("invalid job")
+
+// DuplicatePolicy decides what submitting a job whose unique key is already held does
+type DuplicatePolicy string
+
+const (
+	// DuplicateReturnExisting returns the job holding the key instead of creating a new one
+	DuplicateReturnExisting DuplicatePolicy = "return_existing"
+	// DuplicateConflict fails the submission with ErrDuplicateJob
+	DuplicateConflict DuplicatePolicy = "conflict"
+)
+
+// JobRequest describes a job to be submitted to the manager
+type JobRequest struct {
+	Type             string                      `json:"type"`
+	Payload          string                      `json:"payload"`
+	Queue            string                      `json:"queue,omitempty"`
+	MaxAttempts      int                         `json:"max_attempts,omitempty"`
+	Priority         *int                        `json:"priority,omitempty"`
+	ExpiresAt        *time.Time                  `json:"expires_at,omitempty"`
+	DependsOn        []string                    `json:"depends_on,omitempty"`
+	DependencyPolicy interfaces.DependencyPolicy `json:"dependency_policy,omitempty"`
+	// OrderingKey makes the job wait until every earlier job with the same key has finished
+	OrderingKey string `json:"ordering_key,omitempty"`
+	// CompensationType is enqueued to roll this job back if a later dependent job fails
+	CompensationType    string `json:"compensation_type,omitempty"`
+	CompensationPayload string `json:"compensation_payload,omitempty"`
+	// UniqueKey keeps a second job with the same key out for as long as UniqueScope says
+	UniqueKey   string                 `json:"unique_key,omitempty"`
+	UniqueScope interfaces.UniqueScope `json:"unique_scope,omitempty"`
+	// UniqueWindow is how long a window scoped key is held, such as "10m"
+	UniqueWindow string          `json:"unique_window,omitempty"`
+	OnDuplicate  DuplicatePolicy `json:"on_duplicate,omitempty"`
+
+	compensatesJobID string
+}
+
+// SubmitResult reports the outcome of a single item in a bulk submission
+type SubmitResult struct {
+	Index int    `json:"index"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,210 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+// ErrInvalidJobType is returned when a job type registration is malformed
+var ErrInvalidJobType = errors.New("invalid job type")
+
+// ErrInvalidPayload is returned when a payload does not match the schema of its job type
+var ErrInvalidPayload = errors.New("invalid payload")
+
+// FieldError describes one way a payload breaks the schema of its job type
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// PayloadError lists every field of a payload that does not match the schema of its job type
+type PayloadError struct {
+	Type   string
+	Fields []FieldError
+}
+
+func (e *PayloadError) Error() string {
+	fields := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		fields[i] = field.Field + " " + field.Message
+	}
+	return fmt.Sprintf("%v for job type %s: %s", ErrInvalidPayload, e.Type, strings.Join(fields, "; "))
+}
+
+func (e *PayloadError) Unwrap() error {
+	return ErrInvalidPayload
+}
+
+// defaultMaxRetryDelay caps the backoff of a retry policy that sets no maximum
+const defaultMaxRetryDelay = 5 * time.Minute
+
+// jobTypeCacheTTL is how long registered job types are cached, which bounds how long
+// a change made through another service takes to apply to submissions here
+const jobTypeCacheTTL = 5 * time.Second
+
+// registeredType is a job type with its payload schema parsed
+type registeredType struct {
+	jobType *interfaces.JobType
+	schema  *Schema
+}
+
+// jobTypeCache holds the registered job types so submissions do not read them one by one
+type jobTypeCache struct {
+	mu       sync.Mutex
+	types    map[string]*registeredType
+	loadedAt time.Time
+}
+
+// SetJobType registers a job type or replaces its registration
+func (m *Manager) SetJobType(jobType interfaces.JobType) (*interfaces.JobType, error) {
+	if jobType.Name == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidJobType)
+	}
+	if len(jobType.Name) > maxKeyLength {
+		return nil, fmt.Errorf("%w: name exceeds %d characters", ErrInvalidJobType, maxKeyLength)
+	}
+	if string(jobType.PayloadSchema) == "null" {
+		jobType.PayloadSchema = nil
+	}
+	if len(jobType.PayloadSchema) > 0 {
+		if _, err := ParseSchema(jobType.PayloadSchema); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidJobType, err)
+		}
+	}
+	if len(jobType.DefaultQueue) > maxQueueNameLength {
+		return nil, fmt.Errorf("%w: default queue exceeds %d characters", ErrInvalidJobType, maxQueueNameLength)
+	}
+	if jobType.DefaultMaxAttempts < 0 {
+		return nil, fmt.Errorf("%w: default_max_attempts cannot be negative", ErrInvalidJobType)
+	}
+	if jobType.TimeoutSeconds < 0 {
+		return nil, fmt.Errorf("%w: timeout_seconds cannot be negative", ErrInvalidJobType)
+	}
+	if policy := jobType.RetryPolicy; policy != nil {
+		if policy.BaseDelaySeconds < 1 {
+			return nil, fmt.Errorf("%w: retry_policy.base_delay_seconds must be at least 1", ErrInvalidJobType)
+		}
+		if policy.MaxDelaySeconds != 0 && policy.MaxDelaySeconds < policy.BaseDelaySeconds {
+			return nil, fmt.Errorf("%w: retry_policy.max_delay_seconds cannot be less than base_delay_seconds", ErrInvalidJobType)
+		}
+	}
+
+	jobType.UpdatedAt = time.Now()
+	if err := m.store.SetJobType(&jobType); err != nil {
+		return nil, err
+	}
+	m.jobTypes.invalidate()
+
+	logger.Logger.Info().Str("type", jobType.Name).Bool("schema", len(jobType.PayloadSchema) > 0).Msg("Job type registered")
+	return &jobType, nil
+}
+
+// GetJobType retrieves a registered job type by name
+func (m *Manager) GetJobType(name string) (*interfaces.JobType, error) {
+	return m.store.GetJobType(name)
+}
+
+// GetJobTypes lists the registered job types
+func (m *Manager) GetJobTypes() ([]*interfaces.JobType, error) {
+	return m.store.GetJobTypes()
+}
+
+// DeleteJobType removes a job type registration; its payloads are no longer validated
+func (m *Manager) DeleteJobType(name string) error {
+	if err := m.store.DeleteJobType(name); err != nil {
+		return err
+	}
+	m.jobTypes.invalidate()
+
+	logger.Logger.Info().Str("type", name).Msg("Job type removed")
+	return nil
+}
+
+// lookupJobType returns the registration of a job type; a type that is not registered gets an empty one
+func (m *Manager) lookupJobType(name string) (*registeredType, error) {
+	c := &m.jobTypes
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.types == nil || time.Since(c.loadedAt) >= jobTypeCacheTTL {
+		jobTypes, err := m.store.GetJobTypes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load job types: %w", err)
+		}
+
+		types := make(map[string]*registeredType, len(jobTypes))
+		for _, jobType := range jobTypes {
+			registered := &registeredType{jobType: jobType}
+			if len(jobType.PayloadSchema) > 0 {
+				// Schemas are checked when registered, so one failing here was stored by hand
+				registered.schema, err = ParseSchema(jobType.PayloadSchema)
+				if err != nil {
+					logger.Logger.Error().Err(err).Str("type", jobType.Name).Msg("Ignoring invalid payload schema")
+				}
+			}
+			types[jobType.Name] = registered
+		}
+
+		c.types = types
+		c.loadedAt = time.Now()
+	}
+
+	if registered, ok := c.types[name]; ok {
+		return registered, nil
+	}
+	return &registeredType{jobType: &interfaces.JobType{Name: name}}, nil
+}
+
+// invalidate makes the next lookup read the job types again
+func (c *jobTypeCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.types = nil
+}
+
+// validatePayload checks a payload against the schema of the job type, if the type declares one
+func (r *registeredType) validatePayload(payload string) error {
+	if r.schema == nil {
+		return nil
+	}
+
+	if fields := r.schema.Validate(payload); len(fields) > 0 {
+		return &PayloadError{Type: r.jobType.Name, Fields: fields}
+	}
+	return nil
+}
+
+// scheduleRetry sets when a failed job runs again, following the retry policy of its type if it has one
+func (m *Manager) scheduleRetry(job *interfaces.Job) {
+	registered, err := m.lookupJobType(job.Type)
+	if err != nil {
+		logger.WithJobID(job.ID).Warn().Err(err).Msg("Using the default retry policy")
+	}
+	if err != nil || registered.jobType.RetryPolicy == nil {
+		job.SetRetryAfter(1) // 1 second base delay
+		return
+	}
+
+	policy := registered.jobType.RetryPolicy
+	maxDelay := defaultMaxRetryDelay
+	if policy.MaxDelaySeconds > 0 {
+		maxDelay = time.Duration(policy.MaxDelaySeconds) * time.Second
+	}
+	job.SetRetryAfterWithin(policy.BaseDelaySeconds, maxDelay)
+}
+
+// JobTimeout returns how long a job of the given type may run, or zero if its type sets no timeout
+func (m *Manager) JobTimeout(jobType string) time.Duration {
+	registered, err := m.lookupJobType(jobType)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Str("type", jobType).Msg("Running job without a timeout")
+		return 0
+	}
+	return time.Duration(registered.jobType.TimeoutSeconds) * time.Second
+}
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+// ErrInvalidTypeLimit is returned when a job type concurrency limit is malformed
+var ErrInvalidTypeLimit = errors.New("invalid type limit")
+
+// SetTypeLimit caps how many jobs of a type run at once across all workers; zero removes the cap
+func (m *Manager) SetTypeLimit(jobType string, maxConcurrency int) (*interfaces.TypeLimit, error) {
+	if jobType == "" {
+		return nil, fmt.Errorf("%w: job type cannot be empty", ErrInvalidTypeLimit)
+	}
+	if maxConcurrency < 0 {
+		return nil, fmt.Errorf("%w: max_concurrency cannot be negative", ErrInvalidTypeLimit)
+	}
+
+	limit := &interfaces.TypeLimit{
+		Type:           jobType,
+		MaxConcurrency: maxConcurrency,
+		UpdatedAt:      time.Now(),
+	}
+	if err := m.store.SetTypeLimit(limit); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info().Str("type", jobType).Int("max_concurrency", maxConcurrency).Msg("Type concurrency limit changed")
+	return limit, nil
+}
+
+// GetTypeLimits lists the job type concurrency limits and how many jobs of each type are running
+func (m *Manager) GetTypeLimits() ([]*interfaces.TypeLimit, error) {
+	return m.store.GetTypeLimits()
+}
@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+const (
+	// jobLogPageSize bounds how many log lines are read from the store at once
+	jobLogPageSize = 500
+	// jobLogPollInterval is how often followed logs are checked for new lines
+	jobLogPollInterval = time.Second
+)
+
+// AddJobLog stores a line logged while processing a job
+func (m *Manager) AddJobLog(entry *interfaces.JobLog) error {
+	return m.store.AddJobLog(entry)
+}
+
+// GetJobLogs lists the log lines of a job that come after the line with afterID, oldest first
+func (m *Manager) GetJobLogs(jobID string, afterID int64) ([]*interfaces.JobLog, error) {
+	if _, err := m.store.GetJob(jobID); err != nil {
+		return nil, err
+	}
+	return m.store.GetJobLogs(jobID, afterID, jobLogPageSize)
+}
+
+// FollowJobLogs passes every log line of a job after afterID to send, including lines written while it waits.
+// It returns once the job reached a terminal status and all of its lines were sent, or when ctx is done or send fails.
+func (m *Manager) FollowJobLogs(ctx context.Context, jobID string, afterID int64, send func(entry *interfaces.JobLog) error) error {
+	ticker := time.NewTicker(jobLogPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// The status is read before the lines so lines written just before the job finished are not missed
+		job, err := m.store.GetJob(jobID)
+		if err != nil {
+			return err
+		}
+
+		for {
+			logs, err := m.store.GetJobLogs(jobID, afterID, jobLogPageSize)
+			if err != nil {
+				return err
+			}
+			for _, entry := range logs {
+				if err := send(entry); err != nil {
+					return err
+				}
+				afterID = entry.ID
+			}
+			if len(logs) < jobLogPageSize {
+				break
+			}
+		}
+
+		if job.Status.IsTerminal() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package jobs
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,10 +12,40 @@ import (
 	"github.com/mtr002/Job-Queue/internal/metrics"
 )
 
+const (
+	// DefaultWaitTimeout is used by submit-and-wait callers that do not supply a deadline
+	DefaultWaitTimeout = 30 * time.Second
+	// MaxWaitTimeout caps how long a single submit-and-wait call may block
+	MaxWaitTimeout = 5 * time.Minute
+
+	// waitPollInterval is how often WaitForJob re-reads a job while waiting
+	waitPollInterval = 250 * time.Millisecond
+	// bulkInsertChunkSize bounds how many rows go into a single CreateJobs call
+	bulkInsertChunkSize = 1000
+	// maxQueueNameLength matches the width of the jobs.queue column
+	maxQueueNameLength = 100
+	// expireBatchSize bounds how many stale jobs are expired in one transaction
+	expireBatchSize = 100
+	// maxKeyLength matches the width of the jobs.unique_key and jobs.ordering_key columns
+	maxKeyLength = 255
+)
+
+// ClampWaitTimeout applies the default and maximum to a client-supplied wait timeout
+func ClampWaitTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return DefaultWaitTimeout
+	}
+	if timeout > MaxWaitTimeout {
+		return MaxWaitTimeout
+	}
+	return timeout
+}
+
 // Manager handles job storage and queueing with database persistence
 type Manager struct {
 	store             interfaces.JobStore
 	defaultMaxRetries int
+	jobTypes          jobTypeCache
 }
 
 // NewManager creates a new job manager with database persistence
@@ -30,53 +62,409 @@ func NewManager(store interfaces.JobStore, defaultMaxRetries int) *Manager {
 
 // SubmitJob creates a new job and persists it to the database
 func (m *Manager) SubmitJob(jobType, payload string) (*interfaces.Job, error) {
-	if jobType == "" {
-		return nil, fmt.Errorf("job type cannot be empty")
+	return m.Submit(JobRequest{Type: jobType, Payload: payload})
+}
+
+// Submit creates a new job from a request and persists it to the database.
+// Jobs targeting a draining queue or job type are rejected with ErrQueueDraining.
+func (m *Manager) Submit(req JobRequest) (*interfaces.Job, error) {
+	job, err := m.newJob(req)
+	if err != nil {
+		return nil, err
+	}
+
+	states, err := m.store.GetQueueStates()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDraining(states, job); err != nil {
+		return nil, err
 	}
 
-	job := &interfaces.Job{
-		ID:          uuid.New().String(),
-		Type:        jobType,
-		Payload:     payload,
-		Status:      interfaces.StatusPending,
-		Attempts:    0,
-		MaxAttempts: m.defaultMaxRetries,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	return m.createJob(job, req.OnDuplicate)
+}
+
+// submitFollowUp creates a job triggered by work already in the queue, such as a
+// callback or a compensation, which must not be lost to a drain
+func (m *Manager) submitFollowUp(req JobRequest) (*interfaces.Job, error) {
+	job, err := m.newJob(req)
+	if err != nil {
+		return nil, err
 	}
 
+	return m.createJob(job, req.OnDuplicate)
+}
+
+// createJob persists a validated job and finishes it right away if it cannot run.
+// If the job's unique key is held, the holder is returned or a conflict reported as the policy says.
+func (m *Manager) createJob(job *interfaces.Job, onDuplicate DuplicatePolicy) (*interfaces.Job, error) {
 	if err := m.store.CreateJob(job); err != nil {
-		return nil, fmt.Errorf("failed to create job: %w", err)
+		if errors.Is(err, interfaces.ErrDependencyNotFound) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidJob, err)
+		}
+		var dup *interfaces.DuplicateJobError
+		if !errors.As(err, &dup) {
+			return nil, fmt.Errorf("failed to create job: %w", err)
+		}
+		if onDuplicate == DuplicateConflict {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateJob, dup)
+		}
+
+		logger.WithJobID(dup.ExistingID).Info().Str("unique_key", dup.Key).Msg("Returning existing job for duplicate submission")
+		return m.store.GetJob(dup.ExistingID)
 	}
 
 	metrics.JobsSubmittedTotal.Inc()
 	log := logger.WithJobID(job.ID)
-	log.Info().Str("type", job.Type).Msg("Job submitted successfully")
+	log.Info().Str("type", job.Type).Str("status", string(job.Status)).Msg("Job submitted successfully")
+
+	// A dependency may already have failed, finishing the job on arrival
+	if job.Status.IsTerminal() {
+		m.onJobTerminal(job)
+	}
 	return job, nil
 }
 
+// SubmitJobs validates and bulk inserts many jobs at once.
+// Invalid items are reported per index and do not prevent the others from being stored.
+func (m *Manager) SubmitJobs(reqs []JobRequest) []SubmitResult {
+	results := make([]SubmitResult, len(reqs))
+	valid := make([]*interfaces.Job, 0, len(reqs))
+	validIdx := make([]int, 0, len(reqs))
+
+	states, err := m.store.GetQueueStates()
+	if err != nil {
+		for i := range results {
+			results[i] = SubmitResult{Index: i, Error: err.Error()}
+		}
+		return results
+	}
+
+	individual := 0
+	for i, req := range reqs {
+		results[i].Index = i
+
+		// Dependencies need their edges stored and unique keys need checking, which COPY cannot do
+		if len(req.DependsOn) > 0 || req.UniqueKey != "" {
+			job, err := m.Submit(req)
+			if err != nil {
+				results[i].Error = err.Error()
+				continue
+			}
+			results[i].ID = job.ID
+			individual++
+			continue
+		}
+
+		job, err := m.newJob(req)
+		if err == nil {
+			err = checkDraining(states, job)
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		valid = append(valid, job)
+		validIdx = append(validIdx, i)
+	}
+
+	// A failed chunk is reported on its own items; earlier chunks stay committed
+	accepted := 0
+	for start := 0; start < len(valid); start += bulkInsertChunkSize {
+		end := min(start+bulkInsertChunkSize, len(valid))
+		err := m.store.CreateJobs(valid[start:end])
+		for i := start; i < end; i++ {
+			if err != nil {
+				results[validIdx[i]].Error = fmt.Sprintf("failed to create job: %v", err)
+				continue
+			}
+			results[validIdx[i]].ID = valid[i].ID
+		}
+		if err != nil {
+			logger.Logger.Error().Err(err).Int("chunk_size", end-start).Msg("Failed to insert job chunk")
+			continue
+		}
+		accepted += end - start
+	}
+
+	metrics.JobsSubmittedTotal.Add(float64(accepted))
+	accepted += individual
+	logger.Logger.Info().
+		Int("accepted", accepted).
+		Int("rejected", len(reqs)-accepted).
+		Msg("Bulk job submission processed")
+	return results
+}
+
+// newJob validates a request and builds the job to be stored
+func (m *Manager) newJob(req JobRequest) (*interfaces.Job, error) {
+	if req.Type == "" {
+		return nil, fmt.Errorf("%w: job type cannot be empty", ErrInvalidJob)
+	}
+
+	registered, err := m.lookupJobType(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := registered.validatePayload(req.Payload); err != nil {
+		return nil, err
+	}
+	defaults := registered.jobType
+
+	// Settings missing from the request come from the job type, then from the manager
+	maxAttempts := req.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaults.DefaultMaxAttempts
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = m.defaultMaxRetries
+	}
+
+	queue := req.Queue
+	if queue == "" {
+		queue = defaults.DefaultQueue
+	}
+	if queue == "" {
+		queue = interfaces.DefaultQueue
+	}
+
+	priority := defaults.Priority
+	if req.Priority != nil {
+		priority = *req.Priority
+	}
+	if len(queue) > maxQueueNameLength {
+		return nil, fmt.Errorf("%w: queue name exceeds %d characters", ErrInvalidJob, maxQueueNameLength)
+	}
+	if len(req.OrderingKey) > maxKeyLength {
+		return nil, fmt.Errorf("%w: ordering key exceeds %d characters", ErrInvalidJob, maxKeyLength)
+	}
+
+	policy := req.DependencyPolicy
+	if policy == "" {
+		policy = interfaces.DependencyPolicyFail
+	}
+	if !policy.IsValid() {
+		return nil, fmt.Errorf("%w: unknown dependency policy: %s", ErrInvalidJob, policy)
+	}
+
+	now := time.Now()
+	uniqueScope, uniqueUntil, err := uniqueOptions(req, now)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidJob)
+	}
+
+	return &interfaces.Job{
+		ID:                  uuid.New().String(),
+		Type:                req.Type,
+		Payload:             req.Payload,
+		Status:              interfaces.StatusPending,
+		Queue:               queue,
+		OrderingKey:         req.OrderingKey,
+		Attempts:            0,
+		MaxAttempts:         maxAttempts,
+		Priority:            priority,
+		ExpiresAt:           req.ExpiresAt,
+		DependsOn:           uniqueIDs(req.DependsOn),
+		DependencyPolicy:    policy,
+		CompensationType:    req.CompensationType,
+		CompensationPayload: req.CompensationPayload,
+		CompensatesJobID:    req.compensatesJobID,
+		UniqueKey:           req.UniqueKey,
+		UniqueScope:         uniqueScope,
+		UniqueUntil:         uniqueUntil,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}, nil
+}
+
+// uniqueOptions validates the unique key settings of a request and returns its scope and,
+// for a time window, when the key is released
+func uniqueOptions(req JobRequest, now time.Time) (interfaces.UniqueScope, *time.Time, error) {
+	switch req.OnDuplicate {
+	case "", DuplicateReturnExisting, DuplicateConflict:
+	default:
+		return "", nil, fmt.Errorf("%w: unknown duplicate policy: %s", ErrInvalidJob, req.OnDuplicate)
+	}
+
+	if req.UniqueKey == "" {
+		if req.UniqueScope != "" || req.UniqueWindow != "" {
+			return "", nil, fmt.Errorf("%w: unique_scope and unique_window require a unique_key", ErrInvalidJob)
+		}
+		return "", nil, nil
+	}
+	if len(req.UniqueKey) > maxKeyLength {
+		return "", nil, fmt.Errorf("%w: unique key exceeds %d characters", ErrInvalidJob, maxKeyLength)
+	}
+
+	scope := req.UniqueScope
+	if scope == "" {
+		scope = interfaces.UniquePendingOrRunning
+	}
+	if !scope.IsValid() {
+		return "", nil, fmt.Errorf("%w: unknown unique scope: %s", ErrInvalidJob, scope)
+	}
+
+	if scope != interfaces.UniqueWindow {
+		if req.UniqueWindow != "" {
+			return "", nil, fmt.Errorf("%w: unique_window requires the window unique scope", ErrInvalidJob)
+		}
+		return scope, nil, nil
+	}
+
+	window, err := time.ParseDuration(req.UniqueWindow)
+	if err != nil || window <= 0 {
+		return "", nil, fmt.Errorf("%w: window unique scope requires a positive unique_window duration", ErrInvalidJob)
+	}
+	until := now.Add(window)
+	return scope, &until, nil
+}
+
+// uniqueIDs drops empty and repeated IDs while keeping their order
+func uniqueIDs(ids []string) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
 // GetJob retrieves a job by ID from the database
 func (m *Manager) GetJob(id string) (*interfaces.Job, error) {
 	return m.store.GetJob(id)
 }
 
+// WaitForJob blocks until the job reaches a terminal status or ctx is done.
+// On timeout the last observed state of the job is returned along with ctx.Err().
+func (m *Manager) WaitForJob(ctx context.Context, id string) (*interfaces.Job, error) {
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := m.store.GetJob(id)
+		if err != nil {
+			return nil, err
+		}
+		if job.Status.IsTerminal() {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // GetAllJobs returns all jobs from the database
 func (m *Manager) GetAllJobs() ([]*interfaces.Job, error) {
 	return m.store.GetAllJobs()
 }
 
-// GetPendingJob retrieves the next pending job for processing
-func (m *Manager) GetPendingJob() (*interfaces.Job, error) {
-	return m.store.GetPendingJob()
+// GetPendingJob retrieves the next pending job matching the filter for processing.
+// Jobs that passed their deadline are never handed out, whether ExpireJobs has finished them yet or not.
+// Jobs refused by an empty rate limit bucket are counted per queue or type.
+func (m *Manager) GetPendingJob(filter interfaces.ClaimFilter) (*interfaces.Job, error) {
+	claim, err := m.store.GetPendingJob(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, limit := range claim.RateLimited {
+		metrics.RateLimitedClaimsTotal.WithLabelValues(string(limit.Kind), limit.Name).Inc()
+	}
+	return claim.Job, nil
+}
+
+// GetQueueDepth reports how many jobs are ready to run in the given queues, or in every queue if none are given,
+// and since when the oldest of them has been waiting
+func (m *Manager) GetQueueDepth(queues []string) (*interfaces.QueueDepth, error) {
+	return m.store.GetQueueDepth(queues)
+}
+
+// ExpireJobs finishes the jobs that passed their deadline before they could start.
+// Claims skip such jobs already, so it only has to run periodically to settle their status and dependents.
+func (m *Manager) ExpireJobs() {
+	for {
+		expiries, err := m.store.ExpireJobs(expireBatchSize)
+		if err != nil {
+			logger.Logger.Error().Err(err).Msg("Failed to expire jobs")
+			return
+		}
+
+		for _, expiry := range expiries {
+			metrics.JobsExpiredTotal.Inc()
+			log := logger.WithJobID(expiry.Job.ID)
+			log.Info().Interface("expires_at", expiry.Job.ExpiresAt).Msg("Job expired before it could start")
+
+			m.onJobTerminal(expiry.Job)
+			m.onDependentsResolved(expiry.Job, expiry.Resolved)
+			m.onBatchesFinished(expiry.FinishedBatches)
+		}
+
+		if len(expiries) < expireBatchSize {
+			return
+		}
+	}
+}
+
+// UpdateJobProgress stores the progress and checkpoint reported for a running job
+func (m *Manager) UpdateJobProgress(job *interfaces.Job) error {
+	return m.store.UpdateJobProgress(job)
+}
+
+// ReleaseJob sends a job its worker gave up on before it finished, such as when shutting down, back to pending.
+// The attempt is not counted against the job since the job itself did not fail.
+func (m *Manager) ReleaseJob(job *interfaces.Job) error {
+	release, err := m.store.ReleaseJob(job.ID, job.ClaimToken)
+	if err != nil || release == nil {
+		return err
+	}
+
+	m.onReleased(release)
+	return nil
+}
+
+// onReleased logs a released job, running the follow-up work if it was cancelled as a duplicate instead
+func (m *Manager) onReleased(release *interfaces.Release) {
+	log := logger.WithJobID(release.Job.ID)
+	if !release.Cancelled {
+		metrics.JobsReleasedTotal.Inc()
+		log.Info().Msg("Job released back to the queue")
+		return
+	}
+
+	metrics.JobsCancelledTotal.Inc()
+	log.Warn().Str("unique_key", release.Job.UniqueKey).Str("reason", release.Job.Error).Msg("Released job cancelled as a duplicate")
+
+	m.onJobTerminal(release.Job)
+	m.onDependentsResolved(release.Job, release.Resolved)
+	m.onBatchesFinished(release.FinishedBatches)
 }
 
 // UpdateJobCompleted marks a job as completed with result
 func (m *Manager) UpdateJobCompleted(job *interfaces.Job, result string) error {
+	if err := m.passWorkflowResult(job, result); err != nil {
+		return fmt.Errorf("failed to pass workflow result: %w", err)
+	}
+
 	job.Status = interfaces.StatusCompleted
 	job.Result = result
 	job.UpdatedAt = time.Now()
 
-	if err := m.store.UpdateJob(job); err != nil {
+	if err := m.finishJob(job); err != nil {
 		return fmt.Errorf("failed to update job as completed: %w", err)
 	}
 
@@ -93,9 +481,20 @@ func (m *Manager) UpdateJobFailed(job *interfaces.Job, errorMsg string) error {
 	job.UpdatedAt = time.Now()
 
 	if job.CanRetry() {
+		m.scheduleRetry(job)
+	}
+
+	if job.CanRetry() && job.ExpiresBefore(*job.RetryAfter) {
+		// The next attempt would start after the job's deadline, so there is no point in retrying
+		job.Status = interfaces.StatusExpired
+		job.RetryAfter = nil
+
+		metrics.JobsExpiredTotal.Inc()
+		log := logger.WithJobID(job.ID)
+		log.Info().Int("attempts", job.Attempts).Interface("expires_at", job.ExpiresAt).Msg("Job failed and expires before it could retry")
+	} else if job.CanRetry() {
 		// Job can be retried - set it to retrying status with backoff
 		job.Status = interfaces.StatusRetrying
-		job.SetRetryAfter(1) // 1 second base delay
 
 		log := logger.WithJobID(job.ID)
 		log.Info().
@@ -113,13 +512,66 @@ func (m *Manager) UpdateJobFailed(job *interfaces.Job, errorMsg string) error {
 		log.Info().Int("attempts", job.Attempts).Msg("Job permanently failed")
 	}
 
-	if err := m.store.UpdateJob(job); err != nil {
+	var err error
+	if job.Status.IsTerminal() {
+		err = m.finishJob(job)
+	} else {
+		err = m.store.UpdateJob(job)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to update failed job: %w", err)
 	}
 
 	return nil
 }
 
+// finishJob stores a job that reached a terminal status, resolving the jobs that
+// depend on it in the same transaction, and then runs the follow-up work for all of them
+func (m *Manager) finishJob(job *interfaces.Job) error {
+	resolved, finished, err := m.store.UpdateJobAndResolveDependents(job)
+	if err != nil {
+		return err
+	}
+
+	m.onJobTerminal(job)
+	m.onDependentsResolved(job, resolved)
+	m.onBatchesFinished(finished)
+
+	return nil
+}
+
+// onDependentsResolved logs the dependents a finished job unblocked and runs the follow-up work for those it finished
+func (m *Manager) onDependentsResolved(job *interfaces.Job, resolved []*interfaces.Job) {
+	for _, dep := range resolved {
+		log := logger.WithJobID(dep.ID)
+		if !dep.Status.IsTerminal() {
+			log.Info().Str("parent_id", job.ID).Msg("Job unblocked")
+			continue
+		}
+
+		if dep.Status == interfaces.StatusPermanentFailed {
+			metrics.JobsFailedTotal.Inc()
+		}
+		log.Info().Str("status", string(dep.Status)).Str("reason", dep.Error).Msg("Job finished by failed dependency")
+		m.onJobTerminal(dep)
+	}
+}
+
+// onJobTerminal runs the follow-up work for a job that will not change anymore.
+// The job itself is already stored, so failures here are logged rather than returned.
+func (m *Manager) onJobTerminal(job *interfaces.Job) {
+	if job.WorkflowID != "" {
+		m.refreshWorkflow(job)
+	}
+	if job.CompensatesJobID != "" {
+		m.recordCompensationOutcome(job)
+	}
+	switch job.Status {
+	case interfaces.StatusPermanentFailed, interfaces.StatusExpired, interfaces.StatusCancelled:
+		m.compensate(job)
+	}
+}
+
 // DeleteJob removes a job from the database
 func (m *Manager) DeleteJob(id string) error {
 	return m.store.DeleteJob(id)
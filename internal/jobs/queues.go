@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/logger"
+	"github.com/mtr002/Job-Queue/internal/metrics"
+)
+
+var (
+	// ErrQueueDraining is returned when a job is submitted to a draining queue or job type
+	ErrQueueDraining = errors.New("queue is draining")
+	// ErrInvalidQueueState is returned when a queue state change is malformed
+	ErrInvalidQueueState = errors.New("invalid queue state")
+)
+
+// SetQueueState pauses, drains or resumes a queue or job type
+func (m *Manager) SetQueueState(kind interfaces.QueueControlKind, name string, state interfaces.QueueControlState, reason string) (*interfaces.QueueState, error) {
+	switch kind {
+	case interfaces.QueueControlQueue, interfaces.QueueControlType:
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidQueueState, kind)
+	}
+	switch state {
+	case interfaces.QueueActive, interfaces.QueuePaused, interfaces.QueueDraining:
+	default:
+		return nil, fmt.Errorf("%w: unknown state %q", ErrInvalidQueueState, state)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidQueueState)
+	}
+
+	queueState := &interfaces.QueueState{
+		Kind:      kind,
+		Name:      name,
+		State:     state,
+		Reason:    reason,
+		UpdatedAt: time.Now(),
+	}
+	if err := m.store.SetQueueState(queueState); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info().
+		Str("kind", string(kind)).
+		Str("name", name).
+		Str("state", string(state)).
+		Str("reason", reason).
+		Msg("Queue state changed")
+
+	// Refresh the gauge right away instead of waiting for the next listing
+	if _, err := m.GetQueueStates(); err != nil {
+		logger.Logger.Warn().Err(err).Msg("Failed to refresh queue state metrics")
+	}
+	return queueState, nil
+}
+
+// GetQueueStates lists every paused or draining queue and job type and refreshes the queue state gauge
+func (m *Manager) GetQueueStates() ([]*interfaces.QueueState, error) {
+	states, err := m.store.GetQueueStates()
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.QueueState.Reset()
+	for _, state := range states {
+		metrics.QueueState.WithLabelValues(string(state.Kind), state.Name, string(state.State)).Set(1)
+	}
+	return states, nil
+}
+
+// checkDraining rejects a job whose queue or type is draining
+func checkDraining(states []*interfaces.QueueState, job *interfaces.Job) error {
+	for _, state := range states {
+		if state.State == interfaces.QueueDraining && state.Matches(job) {
+			return fmt.Errorf("%w: %s %s", ErrQueueDraining, state.Kind, state.Name)
+		}
+	}
+	return nil
+}
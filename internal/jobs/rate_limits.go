@@ -0,0 +1,101 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/logger"
+	"github.com/mtr002/Job-Queue/internal/metrics"
+)
+
+// ErrInvalidRateLimit is returned when a rate limit is malformed
+var ErrInvalidRateLimit = errors.New("invalid rate limit")
+
+// SetRateLimit limits how many jobs of a queue or type start per period across all workers.
+// Burst defaults to the limit itself.
+func (m *Manager) SetRateLimit(limit interfaces.RateLimit) (*interfaces.RateLimit, error) {
+	switch limit.Kind {
+	case interfaces.QueueControlQueue, interfaces.QueueControlType:
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidRateLimit, limit.Kind)
+	}
+	if limit.Name == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidRateLimit)
+	}
+	if limit.Limit <= 0 {
+		return nil, fmt.Errorf("%w: limit must be positive", ErrInvalidRateLimit)
+	}
+	if _, ok := interfaces.RateLimitPeriods[limit.Period]; !ok {
+		return nil, fmt.Errorf("%w: period must be second, minute or hour", ErrInvalidRateLimit)
+	}
+	if limit.Burst < 0 {
+		return nil, fmt.Errorf("%w: burst cannot be negative", ErrInvalidRateLimit)
+	}
+	if limit.Burst == 0 {
+		limit.Burst = limit.Limit
+	}
+
+	limit.Tokens = float64(limit.Burst)
+	limit.UpdatedAt = time.Now()
+	if err := m.store.SetRateLimit(&limit); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info().
+		Str("kind", string(limit.Kind)).
+		Str("name", limit.Name).
+		Int("limit", limit.Limit).
+		Str("period", limit.Period).
+		Int("burst", limit.Burst).
+		Msg("Rate limit changed")
+	return &limit, nil
+}
+
+// DeleteRateLimit removes the rate limit of a queue or job type
+func (m *Manager) DeleteRateLimit(kind interfaces.QueueControlKind, name string) error {
+	if err := m.store.DeleteRateLimit(kind, name); err != nil {
+		return err
+	}
+
+	metrics.RateLimitTokens.DeleteLabelValues(string(kind), name)
+	logger.Logger.Info().Str("kind", string(kind)).Str("name", name).Msg("Rate limit removed")
+	return nil
+}
+
+// GetRateLimits lists every rate limit with its available tokens and refreshes the rate limit gauge
+func (m *Manager) GetRateLimits() ([]*interfaces.RateLimit, error) {
+	limits, err := m.store.GetRateLimits()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, limit := range limits {
+		metrics.RateLimitTokens.WithLabelValues(string(limit.Kind), limit.Name).Set(limit.Tokens)
+	}
+	return limits, nil
+}
+
+// RateLimitedFilter returns a claim filter for the given queues that skips every queue and job
+// type whose rate limit has no tokens left, so they do not hold up the others
+func (m *Manager) RateLimitedFilter(queues []string) (interfaces.ClaimFilter, error) {
+	filter := interfaces.ClaimFilter{Queues: queues}
+
+	limits, err := m.store.GetRateLimits()
+	if err != nil {
+		return filter, err
+	}
+
+	for _, limit := range limits {
+		if limit.Tokens >= 1 {
+			continue
+		}
+		if limit.Kind == interfaces.QueueControlQueue {
+			filter.ExcludeQueues = append(filter.ExcludeQueues, limit.Name)
+		} else {
+			filter.ExcludeTypes = append(filter.ExcludeTypes, limit.Name)
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,312 @@
+package jobs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is the subset of JSON Schema a job type can declare for its payloads:
+// type, enum, the numeric and string bounds, pattern, object properties and array items.
+// Other keywords are rejected rather than ignored, and pattern uses Go's RE2 syntax,
+// which has no lookaround or backreferences.
+type Schema struct {
+	Type                 schemaTypes        `json:"type,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *additionalSchema  `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaKeywords are the keywords a schema may use: the ones Schema checks,
+// plus annotations that do not affect validation
+var schemaKeywords = map[string]bool{
+	"type": true, "enum": true, "minimum": true, "maximum": true, "exclusiveMinimum": true, "exclusiveMaximum": true,
+	"minLength": true, "maxLength": true, "pattern": true, "properties": true, "required": true,
+	"additionalProperties": true, "items": true, "minItems": true, "maxItems": true,
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true, "default": true, "examples": true,
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return err
+	}
+	var unsupported []string
+	for keyword := range keywords {
+		if !schemaKeywords[keyword] {
+			unsupported = append(unsupported, keyword)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return fmt.Errorf("unsupported keywords %s", strings.Join(unsupported, ", "))
+	}
+
+	// The alias has no UnmarshalJSON of its own, so this decodes the fields without recursing
+	type plain Schema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// schemaTypes accepts both a single type name and a list of them
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = schemaTypes{name}
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings")
+	}
+	*t = names
+	return nil
+}
+
+// additionalSchema is either a boolean allowing or forbidding unknown properties, or a schema they must match
+type additionalSchema struct {
+	forbidden bool
+	schema    *Schema
+}
+
+func (a *additionalSchema) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.forbidden = !allowed
+		return nil
+	}
+
+	a.schema = &Schema{}
+	return json.Unmarshal(data, a.schema)
+}
+
+// knownSchemaTypes are the JSON Schema type names a schema may use
+var knownSchemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true, "integer": true, "boolean": true, "null": true,
+}
+
+// ParseSchema reads a JSON Schema document, rejecting type names and patterns that cannot be used
+func ParseSchema(raw []byte) (*Schema, error) {
+	schema := &Schema{}
+	if err := json.Unmarshal(raw, schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	if err := schema.compile(); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return schema, nil
+}
+
+// compile checks the schema and its subschemas and prepares their patterns
+func (s *Schema) compile() error {
+	for _, name := range s.Type {
+		if !knownSchemaTypes[name] {
+			return fmt.Errorf("unknown type %q", name)
+		}
+	}
+
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = pattern
+	}
+
+	for name, property := range s.Properties {
+		if property == nil {
+			return fmt.Errorf("property %q has no schema", name)
+		}
+		if err := property.compile(); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.schema != nil {
+		if err := s.AdditionalProperties.schema.compile(); err != nil {
+			return fmt.Errorf("additionalProperties: %w", err)
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.compile(); err != nil {
+			return fmt.Errorf("items: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks a JSON payload against the schema and returns every field that does not match it.
+// Fields are named from "payload", e.g. "payload.user.email" or "payload.items[2]".
+func (s *Schema) Validate(payload string) []FieldError {
+	decoder := json.NewDecoder(strings.NewReader(payload))
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return []FieldError{{Field: "payload", Message: "must be valid JSON"}}
+	}
+
+	var errs []FieldError
+	s.validate(value, "payload", &errs)
+	return errs
+}
+
+func (s *Schema) validate(value any, path string, errs *[]FieldError) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 && !s.matchesType(value) {
+		fail("must be of type %s", strings.Join(s.Type, " or "))
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, allowed := range s.Enum {
+			if reflect.DeepEqual(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("must be one of %s", enumList(s.Enum))
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			fail("must be at least %d characters long", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("must be at most %d characters long", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			fail("must match pattern %s", s.Pattern)
+		}
+
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			fail("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			fail("must be at most %v", *s.Maximum)
+		}
+		if s.ExclusiveMinimum != nil && v <= *s.ExclusiveMinimum {
+			fail("must be greater than %v", *s.ExclusiveMinimum)
+		}
+		if s.ExclusiveMaximum != nil && v >= *s.ExclusiveMaximum {
+			fail("must be less than %v", *s.ExclusiveMaximum)
+		}
+
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, FieldError{Field: path + "." + name, Message: "is required"})
+			}
+		}
+
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if property, ok := s.Properties[name]; ok {
+				property.validate(v[name], path+"."+name, errs)
+				continue
+			}
+			switch {
+			case s.AdditionalProperties == nil:
+			case s.AdditionalProperties.forbidden:
+				*errs = append(*errs, FieldError{Field: path + "." + name, Message: "is not allowed"})
+			case s.AdditionalProperties.schema != nil:
+				s.AdditionalProperties.schema.validate(v[name], path+"."+name, errs)
+			}
+		}
+
+	case []any:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			fail("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			fail("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	}
+}
+
+// matchesType reports whether a decoded JSON value has one of the schema's types
+func (s *Schema) matchesType(value any) bool {
+	for _, name := range s.Type {
+		switch v := value.(type) {
+		case map[string]any:
+			if name == "object" {
+				return true
+			}
+		case []any:
+			if name == "array" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case float64:
+			if name == "number" || (name == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case nil:
+			if name == "null" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// enumList renders the allowed values of an enum for an error message
+func enumList(values []any) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(value); err != nil {
+			parts[i] = fmt.Sprint(value)
+			continue
+		}
+		parts[i] = strings.TrimSpace(buf.String())
+	}
+	return strings.Join(parts, ", ")
+}
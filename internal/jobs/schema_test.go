@@ -0,0 +1,137 @@
+package jobs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSchemaRejectsUnusableSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		errMsg string
+	}{
+		{"invalid JSON", `{"type":`, "invalid schema"},
+		{"unknown type", `{"type": "date"}`, `unknown type "date"`},
+		{"type of wrong kind", `{"type": 5}`, "type must be a string or a list of strings"},
+		{"invalid pattern", `{"type": "string", "pattern": "("}`, `invalid pattern "("`},
+		{"null property", `{"properties": {"name": null}}`, `property "name" has no schema`},
+		{"nested unknown type", `{"properties": {"tags": {"items": {"type": "set"}}}}`, `property "tags": items: unknown type "set"`},
+		{"invalid additional properties", `{"additionalProperties": {"type": "uuid"}}`, `additionalProperties: unknown type "uuid"`},
+		{"unsupported keywords", `{"type": "string", "format": "email", "$ref": "#/defs/email"}`, "unsupported keywords $ref, format"},
+		{"nested unsupported keyword", `{"properties": {"id": {"oneOf": [{"type": "string"}, {"type": "integer"}]}}}`, "unsupported keywords oneOf"},
+		{"lookahead pattern", `{"type": "string", "pattern": "^(?=.*[0-9])"}`, "invalid pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSchema([]byte(tt.schema))
+			if err == nil {
+				t.Fatalf("ParseSchema(%s) succeeded, want error containing %q", tt.schema, tt.errMsg)
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ParseSchema(%s) error = %q, want it to contain %q", tt.schema, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{
+		"type": "object",
+		"required": ["email", "count"],
+		"properties": {
+			"email": {"type": "string", "pattern": "^[^@]+@[^@]+$", "maxLength": 20},
+			"count": {"type": "integer", "minimum": 1, "exclusiveMaximum": 10},
+			"mode": {"enum": ["fast", "safe"]},
+			"name": {"type": ["string", "null"], "minLength": 2},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+			"options": {"type": "object", "additionalProperties": false, "properties": {"dry_run": {"type": "boolean"}}},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseSchema failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+		want    []FieldError
+	}{
+		{
+			name:    "valid",
+			payload: `{"email": "a@b.c", "count": 3, "mode": "safe", "name": null, "tags": ["x"], "options": {"dry_run": true}, "labels": {"team": "ops"}}`,
+		},
+		{
+			name:    "not JSON",
+			payload: `{"email": `,
+			want:    []FieldError{{Field: "payload", Message: "must be valid JSON"}},
+		},
+		{
+			name:    "trailing data",
+			payload: `{"email": "a@b.c", "count": 1} {}`,
+			want:    []FieldError{{Field: "payload", Message: "must be valid JSON"}},
+		},
+		{
+			name:    "wrong root type",
+			payload: `["a@b.c"]`,
+			want:    []FieldError{{Field: "payload", Message: "must be of type object"}},
+		},
+		{
+			name:    "missing required",
+			payload: `{"count": 2}`,
+			want:    []FieldError{{Field: "payload.email", Message: "is required"}},
+		},
+		{
+			name:    "string bounds and pattern",
+			payload: `{"email": "not-an-email-address-at-all", "count": 2, "name": "x"}`,
+			want: []FieldError{
+				{Field: "payload.email", Message: "must be at most 20 characters long"},
+				{Field: "payload.email", Message: "must match pattern ^[^@]+@[^@]+$"},
+				{Field: "payload.name", Message: "must be at least 2 characters long"},
+			},
+		},
+		{
+			name:    "number bounds",
+			payload: `{"email": "a@b.c", "count": 10}`,
+			want:    []FieldError{{Field: "payload.count", Message: "must be less than 10"}},
+		},
+		{
+			name:    "integer type",
+			payload: `{"email": "a@b.c", "count": 1.5}`,
+			want:    []FieldError{{Field: "payload.count", Message: "must be of type integer"}},
+		},
+		{
+			name:    "enum",
+			payload: `{"email": "a@b.c", "count": 1, "mode": "slow"}`,
+			want:    []FieldError{{Field: "payload.mode", Message: `must be one of "fast", "safe"`}},
+		},
+		{
+			name:    "array items",
+			payload: `{"email": "a@b.c", "count": 1, "tags": ["x", 2, "z"]}`,
+			want: []FieldError{
+				{Field: "payload.tags", Message: "must have at most 2 items"},
+				{Field: "payload.tags[1]", Message: "must be of type string"},
+			},
+		},
+		{
+			name:    "additional properties",
+			payload: `{"email": "a@b.c", "count": 1, "options": {"force": true}, "labels": {"team": 1}}`,
+			want: []FieldError{
+				{Field: "payload.labels.team", Message: "must be of type string"},
+				{Field: "payload.options.force", Message: "is not allowed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schema.Validate(tt.payload)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%s) = %+v, want %+v", tt.payload, got, tt.want)
+			}
+		})
+	}
+}
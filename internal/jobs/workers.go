@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/logger"
+	"github.com/mtr002/Job-Queue/internal/metrics"
+)
+
+// workerRetention is how long stopped and dead workers stay in the registry
+const workerRetention = 24 * time.Hour
+
+// RegisterWorker adds a worker service process to the worker registry; its next heartbeat is due within timeout
+func (m *Manager) RegisterWorker(worker *interfaces.Worker, timeout time.Duration) error {
+	return m.store.RegisterWorker(worker, timeout)
+}
+
+// WorkerHeartbeat records that a worker process is alive and what it is running; its next heartbeat is due within timeout.
+// It returns false if the process is no longer active in the registry, such as after it was declared dead.
+func (m *Manager) WorkerHeartbeat(worker *interfaces.Worker, timeout time.Duration) (bool, error) {
+	return m.store.HeartbeatWorker(worker, timeout)
+}
+
+// StopWorker records that a worker process shut down cleanly
+func (m *Manager) StopWorker(id string) error {
+	return m.store.StopWorker(id)
+}
+
+// ListWorkers lists the worker processes in the registry and refreshes the worker process gauge
+func (m *Manager) ListWorkers() ([]*interfaces.Worker, error) {
+	workers, err := m.store.ListWorkers()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[interfaces.WorkerStatus]int{
+		interfaces.WorkerActive:  0,
+		interfaces.WorkerStopped: 0,
+		interfaces.WorkerDead:    0,
+	}
+	for _, worker := range workers {
+		counts[worker.Status]++
+	}
+	for status, count := range counts {
+		metrics.WorkerProcesses.WithLabelValues(string(status)).Set(float64(count))
+	}
+	return workers, nil
+}
+
+// DetectDeadWorkers declares the worker processes that missed their heartbeats dead and releases
+// the jobs they were running back to the queue, since nothing else would ever finish them.
+// A process that was only slow has the outcomes of those jobs refused, as they belong to their next run.
+// Stopped and dead workers older than a day are removed from the registry.
+func (m *Manager) DetectDeadWorkers() {
+	dead, err := m.store.MarkDeadWorkers()
+	if err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to detect dead workers")
+		return
+	}
+
+	for _, worker := range dead {
+		metrics.DeadWorkersTotal.Inc()
+		logger.Logger.Warn().
+			Str("worker", worker.ID).
+			Str("hostname", worker.Hostname).
+			Int("pid", worker.PID).
+			Time("last_heartbeat_at", worker.LastHeartbeatAt).
+			Msg("Worker missed its heartbeats and was declared dead")
+
+		releases, err := m.store.ReleaseWorkerJobs(worker.ID)
+		if err != nil {
+			logger.Logger.Error().Str("worker", worker.ID).Err(err).Msg("Failed to release jobs of dead worker")
+		}
+		for _, release := range releases {
+			m.onReleased(release)
+		}
+	}
+
+	if _, err := m.store.DeleteWorkers(time.Now().Add(-workerRetention)); err != nil {
+		logger.Logger.Error().Err(err).Msg("Failed to remove old workers")
+	}
+}
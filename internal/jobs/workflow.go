@@ -0,0 +1,259 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/logger"
+	"github.com/mtr002/Job-Queue/internal/metrics"
+)
+
+// ErrInvalidWorkflow is returned when a workflow definition fails validation
+var ErrInvalidWorkflow = errors.New("invalid workflow")
+
+// WorkflowStepDefinition describes one step of a workflow
+type WorkflowStepDefinition struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Payload     string   `json:"payload,omitempty"`
+	Queue       string   `json:"queue,omitempty"`
+	MaxAttempts int      `json:"max_attempts,omitempty"`
+	DependsOn   []string `json:"depends_on,omitempty"`
+	// InputFrom names the step whose result becomes this step's payload
+	InputFrom           string `json:"input_from,omitempty"`
+	CompensationType    string `json:"compensation_type,omitempty"`
+	CompensationPayload string `json:"compensation_payload,omitempty"`
+}
+
+// WorkflowDefinition is a named set of steps submitted as one workflow
+type WorkflowDefinition struct {
+	Name  string                   `json:"name"`
+	Steps []WorkflowStepDefinition `json:"steps"`
+	// Output names the step whose result is the workflow output; defaults to the last step
+	Output string `json:"output,omitempty"`
+}
+
+// SubmitWorkflow validates a workflow definition and creates one job per step,
+// wired together with dependencies so each step starts once its inputs succeeded
+func (m *Manager) SubmitWorkflow(def WorkflowDefinition) (*interfaces.Workflow, error) {
+	order, err := validateWorkflow(def)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	workflow := &interfaces.Workflow{
+		ID:         uuid.New().String(),
+		Name:       def.Name,
+		Status:     interfaces.WorkflowRunning,
+		OutputStep: def.Output,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if workflow.OutputStep == "" {
+		workflow.OutputStep = def.Steps[len(def.Steps)-1].Name
+	}
+
+	states, err := m.store.GetQueueStates()
+	if err != nil {
+		return nil, err
+	}
+
+	jobIDs := make(map[string]string, len(def.Steps))
+	stepJobs := make([]*interfaces.Job, 0, len(def.Steps))
+	for _, idx := range order {
+		stepDef := def.Steps[idx]
+
+		job, err := m.newJob(JobRequest{
+			Type:                stepDef.Type,
+			Payload:             stepDef.Payload,
+			Queue:               stepDef.Queue,
+			MaxAttempts:         stepDef.MaxAttempts,
+			CompensationType:    stepDef.CompensationType,
+			CompensationPayload: stepDef.CompensationPayload,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: step %s: %v", ErrInvalidWorkflow, stepDef.Name, err)
+		}
+		if err := checkDraining(states, job); err != nil {
+			return nil, fmt.Errorf("step %s: %w", stepDef.Name, err)
+		}
+
+		job.WorkflowID = workflow.ID
+		for _, dep := range stepDependencies(stepDef) {
+			job.DependsOn = append(job.DependsOn, jobIDs[dep])
+		}
+		if len(job.DependsOn) > 0 {
+			job.Status = interfaces.StatusBlocked
+		}
+		jobIDs[stepDef.Name] = job.ID
+
+		stepJobs = append(stepJobs, job)
+		workflow.Steps = append(workflow.Steps, &interfaces.WorkflowStep{
+			Name:      stepDef.Name,
+			JobID:     job.ID,
+			Type:      job.Type,
+			DependsOn: stepDependencies(stepDef),
+			InputFrom: stepDef.InputFrom,
+			Status:    job.Status,
+		})
+	}
+
+	if err := m.store.CreateWorkflow(workflow, stepJobs); err != nil {
+		return nil, fmt.Errorf("failed to create workflow: %w", err)
+	}
+
+	metrics.JobsSubmittedTotal.Add(float64(len(stepJobs)))
+	logger.Logger.Info().
+		Str("workflow_id", workflow.ID).
+		Str("name", workflow.Name).
+		Int("steps", len(workflow.Steps)).
+		Msg("Workflow submitted successfully")
+	return workflow, nil
+}
+
+// GetWorkflow retrieves a workflow and the state of its steps by ID
+func (m *Manager) GetWorkflow(id string) (*interfaces.Workflow, error) {
+	return m.store.GetWorkflow(id)
+}
+
+// passWorkflowResult hands a finished step's result to the steps reading from it.
+// It runs before the step is marked completed so consumers are never released without input.
+func (m *Manager) passWorkflowResult(job *interfaces.Job, result string) error {
+	if job.WorkflowID == "" {
+		return nil
+	}
+	return m.store.PassWorkflowResult(job.ID, result)
+}
+
+// refreshWorkflow finishes the job's workflow once every step reached a terminal status
+func (m *Manager) refreshWorkflow(job *interfaces.Job) {
+	log := logger.WithJobID(job.ID)
+
+	workflow, err := m.store.GetWorkflow(job.WorkflowID)
+	if err != nil {
+		log.Error().Err(err).Str("workflow_id", job.WorkflowID).Msg("Failed to load workflow")
+		return
+	}
+	if workflow.Status != interfaces.WorkflowRunning {
+		return
+	}
+
+	workflow.Status = interfaces.WorkflowCompleted
+	for _, step := range workflow.Steps {
+		if !step.Status.IsTerminal() {
+			return
+		}
+		if step.Status != interfaces.StatusCompleted && workflow.Error == "" {
+			workflow.Status = interfaces.WorkflowFailed
+			workflow.Error = fmt.Sprintf("step %s %s: %s", step.Name, step.Status, step.Error)
+		}
+		if step.Name == workflow.OutputStep && step.Status == interfaces.StatusCompleted {
+			workflow.Output = step.Result
+		}
+	}
+
+	finished, err := m.store.FinishWorkflow(workflow)
+	if err != nil {
+		log.Error().Err(err).Str("workflow_id", workflow.ID).Msg("Failed to finish workflow")
+		return
+	}
+	if finished {
+		log.Info().
+			Str("workflow_id", workflow.ID).
+			Str("status", string(workflow.Status)).
+			Msg("Workflow finished")
+	}
+}
+
+// stepDependencies returns every step a step waits for, including its input step
+func stepDependencies(step WorkflowStepDefinition) []string {
+	deps := uniqueIDs(step.DependsOn)
+	if step.InputFrom == "" {
+		return deps
+	}
+	for _, dep := range deps {
+		if dep == step.InputFrom {
+			return deps
+		}
+	}
+	return append(deps, step.InputFrom)
+}
+
+// validateWorkflow checks a definition and returns its step indexes in dependency order
+func validateWorkflow(def WorkflowDefinition) ([]int, error) {
+	if def.Name == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidWorkflow)
+	}
+	if len(def.Steps) == 0 {
+		return nil, fmt.Errorf("%w: workflow must contain at least one step", ErrInvalidWorkflow)
+	}
+
+	index := make(map[string]int, len(def.Steps))
+	for i, step := range def.Steps {
+		if step.Name == "" {
+			return nil, fmt.Errorf("%w: step %d has no name", ErrInvalidWorkflow, i)
+		}
+		if _, dup := index[step.Name]; dup {
+			return nil, fmt.Errorf("%w: duplicate step name %s", ErrInvalidWorkflow, step.Name)
+		}
+		index[step.Name] = i
+	}
+
+	if def.Output != "" {
+		if _, ok := index[def.Output]; !ok {
+			return nil, fmt.Errorf("%w: output step %s does not exist", ErrInvalidWorkflow, def.Output)
+		}
+	}
+
+	for _, step := range def.Steps {
+		for _, dep := range stepDependencies(step) {
+			if _, ok := index[dep]; !ok {
+				return nil, fmt.Errorf("%w: step %s depends on unknown step %s", ErrInvalidWorkflow, step.Name, dep)
+			}
+			if dep == step.Name {
+				return nil, fmt.Errorf("%w: step %s depends on itself", ErrInvalidWorkflow, step.Name)
+			}
+		}
+	}
+
+	// Depth-first topological sort; a step seen again while still on the stack closes a cycle
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(def.Steps))
+	order := make([]int, 0, len(def.Steps))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: dependency cycle through step %s", ErrInvalidWorkflow, def.Steps[i].Name)
+		}
+
+		state[i] = visiting
+		for _, dep := range stepDependencies(def.Steps[i]) {
+			if err := visit(index[dep]); err != nil {
+				return err
+			}
+		}
+		state[i] = done
+		order = append(order, i)
+		return nil
+	}
+
+	for i := range def.Steps {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
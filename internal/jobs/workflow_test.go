@@ -0,0 +1,86 @@
+package jobs
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateWorkflow(t *testing.T) {
+	step := func(name string, dependsOn ...string) WorkflowStepDefinition {
+		return WorkflowStepDefinition{Name: name, Type: "echo", DependsOn: dependsOn}
+	}
+
+	tests := []struct {
+		name   string
+		steps  []WorkflowStepDefinition
+		want   []int
+		errMsg string
+	}{
+		{
+			name:  "dependencies before their dependents",
+			steps: []WorkflowStepDefinition{step("report", "fetch", "parse"), step("parse", "fetch"), step("fetch")},
+			want:  []int{2, 1, 0},
+		},
+		{
+			name: "input step counts as a dependency",
+			steps: []WorkflowStepDefinition{
+				{Name: "render", Type: "echo", InputFrom: "fetch"},
+				step("fetch"),
+			},
+			want: []int{1, 0},
+		},
+		{
+			name:  "independent steps keep their order",
+			steps: []WorkflowStepDefinition{step("a"), step("b"), step("c")},
+			want:  []int{0, 1, 2},
+		},
+		{
+			name:   "step depending on itself",
+			steps:  []WorkflowStepDefinition{step("a", "a")},
+			errMsg: "step a depends on itself",
+		},
+		{
+			name:   "two step cycle",
+			steps:  []WorkflowStepDefinition{step("a", "b"), step("b", "a")},
+			errMsg: "dependency cycle through step a",
+		},
+		{
+			name:   "cycle behind a valid step",
+			steps:  []WorkflowStepDefinition{step("start"), step("a", "start", "c"), step("b", "a"), step("c", "b")},
+			errMsg: "dependency cycle through step a",
+		},
+		{
+			name: "cycle through an input step",
+			steps: []WorkflowStepDefinition{
+				{Name: "a", Type: "echo", InputFrom: "b"},
+				step("b", "a"),
+			},
+			errMsg: "dependency cycle through step a",
+		},
+		{
+			name:   "unknown dependency",
+			steps:  []WorkflowStepDefinition{step("a", "missing")},
+			errMsg: "step a depends on unknown step missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateWorkflow(WorkflowDefinition{Name: "test", Steps: tt.steps})
+			if tt.errMsg != "" {
+				if !errors.Is(err, ErrInvalidWorkflow) || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("validateWorkflow() error = %v, want ErrInvalidWorkflow containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateWorkflow() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateWorkflow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/rs/zerolog"
@@ -47,3 +48,12 @@ func WithCorrelationID(correlationID string) *zerolog.Logger {
 	return &l
 }
 
+// SetLevel changes the minimum level logged, one of debug, info, warn or error
+func SetLevel(level string) error {
+	logLevel, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	zerolog.SetGlobalLevel(logLevel)
+	return nil
+}
@@ -21,6 +21,26 @@ var (
 		Help: "Total number of jobs that failed permanently",
 	})
 
+	JobsExpiredTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "jobqueue_jobs_expired_total",
+		Help: "Total number of jobs discarded because they passed their deadline before running",
+	})
+
+	JobsCancelledTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "jobqueue_jobs_cancelled_total",
+		Help: "Total number of jobs cancelled before they started running",
+	})
+
+	JobsReleasedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "jobqueue_jobs_released_total",
+		Help: "Total number of running jobs handed back to the queue because their worker shut down or died before they finished",
+	})
+
+	JobsManuallyRetriedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "jobqueue_jobs_manually_retried_total",
+		Help: "Total number of finished jobs sent back to pending by an operator",
+	})
+
 	JobProcessingDuration = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "jobqueue_job_processing_duration_seconds",
 		Help:    "Time taken to process jobs in seconds",
@@ -32,8 +52,48 @@ var (
 		Help: "Current number of active workers",
 	})
 
+	WorkerPoolTargetSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jobqueue_worker_pool_target_size",
+		Help: "Number of workers each pool is sized to, as configured or as chosen by its autoscaler",
+	}, []string{"queues"})
+
+	ConfigVersion = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "jobqueue_config_version",
+		Help: "Version of the running configuration, starting at 1 and incremented by each successful reload",
+	})
+
+	ConfigReloadsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobqueue_config_reloads_total",
+		Help: "Total number of configuration reloads by result",
+	}, []string{"result"})
+
 	PendingJobs = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "jobqueue_pending_jobs",
 		Help: "Current number of pending jobs",
 	})
+
+	QueueState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jobqueue_queue_state",
+		Help: "Set to 1 for each queue or job type that is paused or draining",
+	}, []string{"kind", "name", "state"})
+
+	WorkerProcesses = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jobqueue_worker_processes",
+		Help: "Number of worker service processes in the worker registry by status",
+	}, []string{"status"})
+
+	DeadWorkersTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "jobqueue_dead_workers_total",
+		Help: "Total number of worker service processes declared dead after missing their heartbeats",
+	})
+
+	RateLimitTokens = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jobqueue_rate_limit_tokens",
+		Help: "Jobs of a rate limited queue or job type that may start right now",
+	}, []string{"kind", "name"})
+
+	RateLimitedClaimsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobqueue_rate_limited_claims_total",
+		Help: "Number of claims refused a job because its queue or job type rate limit had no tokens left",
+	}, []string{"kind", "name"})
 )
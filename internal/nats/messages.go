@@ -1,9 +1,31 @@
 package nats
 
+import (
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+)
+
 type JobSubmissionMessage struct {
-	Type        string `json:"type"`
-	Payload     string `json:"payload"`
-	MaxAttempts int    `json:"max_attempts"`
+	Type             string   `json:"type"`
+	Payload          string   `json:"payload"`
+	Queue            string   `json:"queue,omitempty"`
+	MaxAttempts      int      `json:"max_attempts"`
+	DependsOn        []string `json:"depends_on,omitempty"`
+	DependencyPolicy string   `json:"dependency_policy,omitempty"`
+
+	CompensationType    string `json:"compensation_type,omitempty"`
+	CompensationPayload string `json:"compensation_payload,omitempty"`
+
+	UniqueKey    string `json:"unique_key,omitempty"`
+	UniqueScope  string `json:"unique_scope,omitempty"`
+	UniqueWindow string `json:"unique_window,omitempty"`
+	OnDuplicate  string `json:"on_duplicate,omitempty"`
+	OrderingKey  string `json:"ordering_key,omitempty"`
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Priority  *int       `json:"priority,omitempty"`
 }
 
 type JobStatusMessage struct {
@@ -13,3 +35,44 @@ type JobStatusMessage struct {
 	Error   string `json:"error,omitempty"`
 }
 
+// NewJobSubmissionMessage builds the message published for a job request
+func NewJobSubmissionMessage(req jobs.JobRequest) *JobSubmissionMessage {
+	return &JobSubmissionMessage{
+		Type:                req.Type,
+		Payload:             req.Payload,
+		Queue:               req.Queue,
+		MaxAttempts:         req.MaxAttempts,
+		DependsOn:           req.DependsOn,
+		DependencyPolicy:    string(req.DependencyPolicy),
+		CompensationType:    req.CompensationType,
+		CompensationPayload: req.CompensationPayload,
+		UniqueKey:           req.UniqueKey,
+		UniqueScope:         string(req.UniqueScope),
+		UniqueWindow:        req.UniqueWindow,
+		OnDuplicate:         string(req.OnDuplicate),
+		OrderingKey:         req.OrderingKey,
+		ExpiresAt:           req.ExpiresAt,
+		Priority:            req.Priority,
+	}
+}
+
+// JobRequest converts the message into a request for the job manager
+func (m *JobSubmissionMessage) JobRequest() jobs.JobRequest {
+	return jobs.JobRequest{
+		Type:                m.Type,
+		Payload:             m.Payload,
+		Queue:               m.Queue,
+		MaxAttempts:         m.MaxAttempts,
+		DependsOn:           m.DependsOn,
+		DependencyPolicy:    interfaces.DependencyPolicy(m.DependencyPolicy),
+		CompensationType:    m.CompensationType,
+		CompensationPayload: m.CompensationPayload,
+		UniqueKey:           m.UniqueKey,
+		UniqueScope:         interfaces.UniqueScope(m.UniqueScope),
+		UniqueWindow:        m.UniqueWindow,
+		OnDuplicate:         jobs.DuplicatePolicy(m.OnDuplicate),
+		OrderingKey:         m.OrderingKey,
+		ExpiresAt:           m.ExpiresAt,
+		Priority:            m.Priority,
+	}
+}
@@ -37,7 +37,7 @@ func (s *Server) Subscribe() error {
 			return
 		}
 
-		_, err := s.manager.SubmitJob(jobMsg.Type, jobMsg.Payload)
+		_, err := s.manager.Submit(jobMsg.JobRequest())
 		if err != nil {
 			return
 		}
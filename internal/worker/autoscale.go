@@ -0,0 +1,176 @@
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+	"github.com/mtr002/Job-Queue/internal/metrics"
+)
+
+// AutoscalePolicy tunes how autoscaling pools follow their queues
+type AutoscalePolicy struct {
+	// Interval is how often the queues are checked
+	Interval time.Duration
+	// MaxWait adds a worker while the oldest ready job has waited longer than this, even if the counts say there are enough
+	MaxWait time.Duration
+	// ScaleDownDelay is how long a pool must need fewer workers than it runs before it shrinks
+	ScaleDownDelay time.Duration
+}
+
+// DefaultAutoscalePolicy is used by pools whose policy leaves a setting unset
+var DefaultAutoscalePolicy = AutoscalePolicy{
+	Interval:       5 * time.Second,
+	MaxWait:        10 * time.Second,
+	ScaleDownDelay: time.Minute,
+}
+
+// Autoscaler resizes a pool between its minimum and maximum worker counts from the depth of the queues it serves.
+// It grows the pool as soon as the ready jobs outnumber its idle workers, but only shrinks it once the pool
+// has needed fewer workers for the whole ScaleDownDelay, so bursts do not make it flap.
+type Autoscaler struct {
+	pool    *Pool
+	manager *jobs.Manager
+	label   string
+
+	mu         sync.Mutex
+	minWorkers int
+	maxWorkers int
+	policy     AutoscalePolicy
+	overSince  time.Time // When the pool started running more workers than it needs; zero while it does not
+	peak       int       // Most workers needed since overSince
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewAutoscaler creates an autoscaler for a started pool; Start runs it
+func NewAutoscaler(pool *Pool, manager *jobs.Manager, minWorkers, maxWorkers int, policy AutoscalePolicy) *Autoscaler {
+	a := &Autoscaler{
+		pool:    pool,
+		manager: manager,
+		label:   poolLabel(pool.Queues()),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	a.Update(minWorkers, maxWorkers, policy)
+	return a
+}
+
+// poolLabel names a pool in metrics by its queues
+func poolLabel(queues []string) string {
+	if len(queues) == 0 {
+		return "*"
+	}
+	return poolKey(queues)
+}
+
+// Update changes the bounds and policy of the autoscaler, bringing the pool within the new bounds right away
+func (a *Autoscaler) Update(minWorkers, maxWorkers int, policy AutoscalePolicy) {
+	if policy.Interval <= 0 {
+		policy.Interval = DefaultAutoscalePolicy.Interval
+	}
+	if policy.MaxWait <= 0 {
+		policy.MaxWait = DefaultAutoscalePolicy.MaxWait
+	}
+	if policy.ScaleDownDelay < 0 {
+		policy.ScaleDownDelay = 0
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.minWorkers = minWorkers
+	a.maxWorkers = maxWorkers
+	a.policy = policy
+	a.resize(a.clamp(a.pool.Size()))
+}
+
+// Start checks the pool's queues every policy interval until Stop is called
+func (a *Autoscaler) Start() {
+	go a.run()
+}
+
+// Stop stops resizing the pool, leaving it at its current size
+func (a *Autoscaler) Stop() {
+	close(a.stop)
+	<-a.done
+}
+
+func (a *Autoscaler) run() {
+	defer close(a.done)
+
+	interval := a.interval()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+			if current := a.interval(); current != interval {
+				interval = current
+				ticker.Reset(interval)
+			}
+
+			depth, err := a.manager.GetQueueDepth(a.pool.Queues())
+			if err != nil {
+				logger.Logger.Error().Err(err).Strs("queues", a.pool.Queues()).Msg("Failed to get queue depth for autoscaling")
+				continue
+			}
+			a.evaluate(depth, time.Now())
+		}
+	}
+}
+
+func (a *Autoscaler) interval() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.policy.Interval
+}
+
+// evaluate resizes the pool for the given queue depth observed at now
+func (a *Autoscaler) evaluate(depth *interfaces.QueueDepth, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	size := a.pool.Size()
+
+	// Every running job keeps its worker and every ready job wants one
+	needed := a.pool.Busy() + depth.Ready
+	// A job left waiting too long means the pool is not keeping up, whatever the counts say
+	if depth.OldestReadyAt != nil && now.Sub(*depth.OldestReadyAt) > a.policy.MaxWait && needed <= size {
+		needed = size + 1
+	}
+	needed = a.clamp(needed)
+
+	switch {
+	case needed >= size:
+		a.overSince = time.Time{}
+		a.resize(needed)
+
+	case a.overSince.IsZero():
+		a.overSince = now
+		a.peak = needed
+
+	default:
+		a.peak = max(a.peak, needed)
+		if now.Sub(a.overSince) >= a.policy.ScaleDownDelay {
+			a.overSince = time.Time{}
+			a.resize(a.peak)
+		}
+	}
+}
+
+func (a *Autoscaler) clamp(workers int) int {
+	return min(max(workers, a.minWorkers), a.maxWorkers)
+}
+
+// resize sets the pool to the given size and reports it as the pool's target
+func (a *Autoscaler) resize(workers int) {
+	a.pool.Resize(workers)
+	metrics.WorkerPoolTargetSize.WithLabelValues(a.label).Set(float64(workers))
+}
@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+)
+
+func TestAutoscalerEvaluate(t *testing.T) {
+	type step struct {
+		after  time.Duration // Since the first evaluation
+		ready  int
+		waited time.Duration // How long the oldest ready job has waited; none if zero
+		want   int
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name:  "grows right away",
+			steps: []step{{0, 3, 0, 3}},
+		},
+		{
+			name:  "stays within the maximum",
+			steps: []step{{0, 10, 0, 5}},
+		},
+		{
+			name:  "adds a worker when a job waits too long",
+			steps: []step{{0, 1, 15 * time.Second, 2}},
+		},
+		{
+			name:  "shrinks only after the delay",
+			steps: []step{{0, 3, 0, 3}, {10 * time.Second, 0, 0, 3}, {50 * time.Second, 0, 0, 3}, {70 * time.Second, 0, 0, 1}},
+		},
+		{
+			name:  "shrinks to the most workers needed during the delay",
+			steps: []step{{0, 4, 0, 4}, {10 * time.Second, 1, 0, 4}, {40 * time.Second, 2, 0, 4}, {70 * time.Second, 0, 0, 2}},
+		},
+		{
+			name: "demand back at the pool size restarts the delay",
+			steps: []step{
+				{0, 3, 0, 3}, {10 * time.Second, 0, 0, 3}, {50 * time.Second, 3, 0, 3},
+				{60 * time.Second, 0, 0, 3}, {110 * time.Second, 0, 0, 3}, {120 * time.Second, 0, 0, 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewPool(nil, nil, 1)
+			// The workers never poll, so the pool needs no database
+			pool.SetPollInterval(time.Hour)
+			t.Cleanup(pool.Stop)
+
+			a := NewAutoscaler(pool, nil, 1, 5, AutoscalePolicy{MaxWait: 10 * time.Second, ScaleDownDelay: time.Minute})
+			start := time.Now()
+			for i, s := range tt.steps {
+				now := start.Add(s.after)
+				depth := &interfaces.QueueDepth{Ready: s.ready}
+				if s.waited > 0 {
+					oldest := now.Add(-s.waited)
+					depth.OldestReadyAt = &oldest
+				}
+
+				a.evaluate(depth, now)
+				if got := pool.Size(); got != s.want {
+					t.Fatalf("step %d: pool has %d workers, want %d", i, got, s.want)
+				}
+			}
+		})
+	}
+}
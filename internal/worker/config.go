@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PoolConfig describes one worker pool and the queues it serves
+type PoolConfig struct {
+	Queues      []string
+	WorkerCount int
+	// MaxWorkers makes the pool autoscale between WorkerCount and MaxWorkers when it is above WorkerCount
+	MaxWorkers int
+}
+
+// Autoscaled reports whether the pool's size follows its queues instead of staying at WorkerCount
+func (c PoolConfig) Autoscaled() bool {
+	return c.MaxWorkers > c.WorkerCount
+}
+
+// ParsePoolConfigs parses a pool spec such as "default,email:5;reports:1-8".
+// Pools are separated by ';', each pool lists its queues separated by ',' followed by ':' and its worker count,
+// or by the minimum and maximum worker counts separated by '-' for a pool that autoscales.
+func ParsePoolConfigs(spec string) ([]PoolConfig, error) {
+	var configs []PoolConfig
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		queueList, countStr, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("pool %q is missing a worker count", entry)
+		}
+
+		minStr, maxStr, autoscaled := strings.Cut(countStr, "-")
+		count, err := strconv.Atoi(strings.TrimSpace(minStr))
+		if err != nil || count <= 0 {
+			return nil, fmt.Errorf("pool %q has an invalid worker count", entry)
+		}
+		maxCount := count
+		if autoscaled {
+			maxCount, err = strconv.Atoi(strings.TrimSpace(maxStr))
+			if err != nil || maxCount < count {
+				return nil, fmt.Errorf("pool %q has an invalid maximum worker count", entry)
+			}
+		}
+
+		var queues []string
+		for _, queue := range strings.Split(queueList, ",") {
+			if queue = strings.TrimSpace(queue); queue != "" {
+				queues = append(queues, queue)
+			}
+		}
+		if len(queues) == 0 {
+			return nil, fmt.Errorf("pool %q does not name any queues", entry)
+		}
+
+		configs = append(configs, PoolConfig{Queues: queues, WorkerCount: count, MaxWorkers: maxCount})
+	}
+
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no worker pools configured")
+	}
+
+	return configs, nil
+}
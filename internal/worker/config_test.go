@@ -0,0 +1,60 @@
+package worker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePoolConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   string
+		want   []PoolConfig
+		errMsg string
+	}{
+		{
+			name: "fixed pools",
+			spec: "default,email:5;reports:1",
+			want: []PoolConfig{
+				{Queues: []string{"default", "email"}, WorkerCount: 5, MaxWorkers: 5},
+				{Queues: []string{"reports"}, WorkerCount: 1, MaxWorkers: 1},
+			},
+		},
+		{
+			name: "autoscaled pool",
+			spec: "reports:1-8",
+			want: []PoolConfig{{Queues: []string{"reports"}, WorkerCount: 1, MaxWorkers: 8}},
+		},
+		{
+			name: "spaces and empty entries",
+			spec: " default , email : 2 - 4 ;; ",
+			want: []PoolConfig{{Queues: []string{"default", "email"}, WorkerCount: 2, MaxWorkers: 4}},
+		},
+		{name: "empty spec", spec: " ; ", errMsg: "no worker pools configured"},
+		{name: "missing count", spec: "default", errMsg: "is missing a worker count"},
+		{name: "count not a number", spec: "default:many", errMsg: "has an invalid worker count"},
+		{name: "zero count", spec: "default:0", errMsg: "has an invalid worker count"},
+		{name: "maximum below minimum", spec: "default:4-2", errMsg: "has an invalid maximum worker count"},
+		{name: "maximum not a number", spec: "default:1-", errMsg: "has an invalid maximum worker count"},
+		{name: "no queues", spec: " , :2", errMsg: "does not name any queues"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePoolConfigs(tt.spec)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("ParsePoolConfigs(%q) error = %v, want it to contain %q", tt.spec, err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePoolConfigs(%q) failed: %v", tt.spec, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePoolConfigs(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+const (
+	// maxJobLogLines bounds how many lines are stored for a single attempt of a job
+	maxJobLogLines = 1000
+	// maxJobLogLineLength bounds the length of a stored line in bytes
+	maxJobLogLineLength = 4096
+)
+
+type jobLoggerKey struct{}
+
+// withJobLogger returns a context carrying a logger whose lines are also stored with job
+func withJobLogger(ctx context.Context, manager *jobs.Manager, job *interfaces.Job) context.Context {
+	hook := &jobLogHook{manager: manager, jobID: job.ID, attempt: job.Attempts + 1}
+	l := logger.WithJobID(job.ID).With().Int("attempt", hook.attempt).Logger().Hook(hook)
+	return context.WithValue(ctx, jobLoggerKey{}, &l)
+}
+
+// Logger returns the logger for the job being processed. Its lines go to the service log and are
+// also stored with the job, where they can be read through GET /jobs/{id}/logs.
+// It returns the service logger when ctx does not belong to a job.
+func Logger(ctx context.Context) *zerolog.Logger {
+	if l, ok := ctx.Value(jobLoggerKey{}).(*zerolog.Logger); ok {
+		return l
+	}
+	return &logger.Logger
+}
+
+// jobLogHook stores the lines logged for one attempt of a job, up to maxJobLogLines
+type jobLogHook struct {
+	mu      sync.Mutex
+	manager *jobs.Manager
+	jobID   string
+	attempt int
+	lines   int
+}
+
+// Run implements zerolog.Hook
+func (h *jobLogHook) Run(_ *zerolog.Event, level zerolog.Level, message string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.lines > maxJobLogLines {
+		return
+	}
+	h.lines++
+	if h.lines > maxJobLogLines {
+		// One last line records that the rest was dropped
+		level = zerolog.WarnLevel
+		message = fmt.Sprintf("log limit of %d lines reached, further lines are not stored", maxJobLogLines)
+	}
+	if len(message) > maxJobLogLineLength {
+		message = strings.ToValidUTF8(message[:maxJobLogLineLength], "") + "..."
+	}
+
+	entry := &interfaces.JobLog{
+		JobID:     h.jobID,
+		Attempt:   h.attempt,
+		Level:     level.String(),
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+	if err := h.manager.AddJobLog(entry); err != nil {
+		logger.WithJobID(h.jobID).Error().Err(err).Msg("Failed to store job log")
+	}
+}
@@ -3,9 +3,12 @@ package worker
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mtr002/Job-Queue/internal/interfaces"
@@ -14,62 +17,205 @@ import (
 	"github.com/mtr002/Job-Queue/internal/metrics"
 )
 
-// JobProcessor defines the interface for processing different job types
+// JobProcessor defines the interface for processing different job types.
+// Handlers can report progress and save checkpoints through ctx with ReportProgress and SaveCheckpoint,
+// and log lines stored with the job through Logger(ctx).
 type JobProcessor interface {
-	Process(job *interfaces.Job) (string, error)
+	Process(ctx context.Context, job *interfaces.Job) (string, error)
 }
 
 // Pool represents a worker pool that processes jobs from database
 type Pool struct {
 	manager      *jobs.Manager
 	processor    JobProcessor
-	ctx          context.Context
+	ctx          context.Context // Cancelled when the pool stops claiming jobs
 	cancel       context.CancelFunc
+	jobCtx       context.Context // Seen by running handlers; only cancelled once their jobs have been released
+	jobCancel    context.CancelFunc
 	wg           sync.WaitGroup
+	mu           sync.Mutex
 	workerCount  int
-	pollInterval time.Duration // How often to poll for new jobs
+	workers      []chan struct{} // Retire channels of the running workers, closed to let one exit after its current job
+	nextID       int
+	running      map[int]*runningJob // Job each worker is running, by worker ID; whoever removes a job owns its outcome
+	queues       []string            // Queues this pool claims from, all queues if empty
+	claimant     string              // ID of the worker process the pool claims jobs for, recorded on the jobs it claims
+	pollInterval atomic.Int64        // How often to poll for new jobs, as a time.Duration
+	busy         atomic.Int64        // Workers running a job, including removed ones finishing theirs
 }
 
-// NewPool creates a new worker pool with database polling
-func NewPool(manager *jobs.Manager, processor JobProcessor, workerCount int) *Pool {
+// runningJob is a job a worker claimed and when it started running it
+type runningJob struct {
+	job       *interfaces.Job
+	startedAt time.Time
+}
+
+// NewPool creates a new worker pool with database polling.
+// The pool only claims jobs from the given queues, or from every queue if none are given.
+func NewPool(manager *jobs.Manager, processor JobProcessor, workerCount int, queues ...string) *Pool {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &Pool{
-		manager:      manager,
-		processor:    processor,
-		workerCount:  workerCount,
-		ctx:          ctx,
-		cancel:       cancel,
-		pollInterval: 1 * time.Second, // Poll every second
+	jobCtx, jobCancel := context.WithCancel(context.Background())
+	p := &Pool{
+		manager:     manager,
+		processor:   processor,
+		workerCount: workerCount,
+		running:     make(map[int]*runningJob),
+		queues:      queues,
+		ctx:         ctx,
+		cancel:      cancel,
+		jobCtx:      jobCtx,
+		jobCancel:   jobCancel,
 	}
+	p.pollInterval.Store(int64(time.Second)) // Poll every second
+	return p
 }
 
 // Start begins processing jobs with the specified number of workers
 func (p *Pool) Start() {
-	logger.Logger.Info().Int("worker_count", p.workerCount).Msg("Starting worker pool")
-	metrics.ActiveWorkers.Set(float64(p.workerCount))
+	logger.Logger.Info().Int("worker_count", p.workerCount).Strs("queues", p.queues).Msg("Starting worker pool")
+	p.Resize(p.workerCount)
+}
+
+// Resize changes the number of workers of a started pool.
+// Workers that are removed finish the job they are running before they exit; Resize does not wait for them.
+func (p *Pool) Resize(workerCount int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	for i := 0; i < p.workerCount; i++ {
+	if p.ctx.Err() != nil {
+		return
+	}
+	if len(p.workers) != workerCount {
+		logger.Logger.Info().Int("from", len(p.workers)).Int("to", workerCount).Strs("queues", p.queues).Msg("Resizing worker pool")
+	}
+
+	for len(p.workers) < workerCount {
+		retire := make(chan struct{})
+		p.workers = append(p.workers, retire)
 		p.wg.Add(1)
-		go p.worker(i)
+		go p.worker(p.nextID, retire)
+		p.nextID++
+	}
+	for len(p.workers) > workerCount {
+		last := len(p.workers) - 1
+		close(p.workers[last])
+		p.workers = p.workers[:last]
+	}
+	p.workerCount = workerCount
+}
+
+// Size returns the number of workers the pool is running, not counting removed ones still finishing a job
+func (p *Pool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.workers)
+}
+
+// Busy returns the number of workers running a job
+func (p *Pool) Busy() int {
+	return int(p.busy.Load())
+}
+
+// RunningJobs returns the job each of the pool's workers is running, ordered by worker
+func (p *Pool) RunningJobs() []*interfaces.WorkerJob {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	jobs := make([]*interfaces.WorkerJob, 0, len(p.running))
+	for workerID, running := range p.running {
+		jobs = append(jobs, &interfaces.WorkerJob{
+			Pool:      poolLabel(p.queues),
+			Worker:    workerID,
+			JobID:     running.job.ID,
+			Type:      running.job.Type,
+			StartedAt: running.startedAt,
+		})
 	}
+	slices.SortFunc(jobs, func(a, b *interfaces.WorkerJob) int { return a.Worker - b.Worker })
+	return jobs
 }
 
-// Stop gracefully shuts down the worker pool
+// Queues returns the queues the pool claims jobs from, or nil for every queue
+func (p *Pool) Queues() []string {
+	return p.queues
+}
+
+// SetPollInterval changes how often idle workers look for a job, taking effect at their next poll
+func (p *Pool) SetPollInterval(interval time.Duration) {
+	p.pollInterval.Store(int64(interval))
+}
+
+// Stop gracefully shuts down the worker pool, waiting for its in-flight jobs however long they take
 func (p *Pool) Stop() {
-	logger.Logger.Info().Msg("Stopping worker pool")
+	p.Shutdown(context.Background())
+}
+
+// Shutdown stops the pool from claiming jobs and waits for its in-flight jobs to finish until ctx is done.
+// Jobs still running then are released back to the queue and their handlers' contexts cancelled;
+// Shutdown returns ctx's error without waiting for those handlers to return.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	logger.Logger.Info().Strs("queues", p.queues).Msg("Stopping worker pool")
+	p.mu.Lock()
 	p.cancel()
-	p.wg.Wait()
-	metrics.ActiveWorkers.Set(0)
-	logger.Logger.Info().Msg("Worker pool stopped")
+	p.workers = nil
+	p.mu.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		logger.Logger.Info().Msg("Worker pool stopped")
+		return nil
+	case <-ctx.Done():
+	}
+
+	p.mu.Lock()
+	running := p.running
+	p.running = make(map[int]*runningJob)
+	p.mu.Unlock()
+
+	for workerID, running := range running {
+		logger.Logger.Warn().Int("worker_id", workerID).Str("job_id", running.job.ID).Msg("Releasing job still running at shutdown")
+		if err := p.manager.ReleaseJob(running.job); err != nil {
+			logger.Logger.Error().Int("worker_id", workerID).Str("job_id", running.job.ID).Err(err).Msg("Failed to release job")
+		}
+	}
+	p.jobCancel()
+
+	logger.Logger.Warn().Int("released", len(running)).Strs("queues", p.queues).Msg("Worker pool stopped before its jobs finished")
+	return ctx.Err()
+}
+
+// track records the job a worker is running
+func (p *Pool) track(workerID int, job *interfaces.Job, startedAt time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.running[workerID] = &runningJob{job: job, startedAt: startedAt}
 }
 
-// worker is the main worker goroutine that polls database for jobs
-func (p *Pool) worker(id int) {
+// untrack removes the job a worker was running, reporting false if Shutdown released it in the meantime
+func (p *Pool) untrack(workerID int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.running[workerID]
+	delete(p.running, workerID)
+	return ok
+}
+
+// worker is the main worker goroutine that polls database for jobs until the pool stops or retire is closed
+func (p *Pool) worker(id int, retire <-chan struct{}) {
 	defer p.wg.Done()
 
+	metrics.ActiveWorkers.Inc()
+	defer metrics.ActiveWorkers.Dec()
 	logger.Logger.Info().Int("worker_id", id).Msg("Worker started")
 
-	ticker := time.NewTicker(p.pollInterval)
+	interval := time.Duration(p.pollInterval.Load())
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
@@ -77,15 +223,33 @@ func (p *Pool) worker(id int) {
 		case <-p.ctx.Done():
 			logger.Logger.Info().Int("worker_id", id).Msg("Worker shutting down")
 			return
+		case <-retire:
+			logger.Logger.Info().Int("worker_id", id).Msg("Worker removed from pool")
+			return
 		case <-ticker.C:
-			job, err := p.manager.GetPendingJob()
+			if current := time.Duration(p.pollInterval.Load()); current != interval {
+				interval = current
+				ticker.Reset(interval)
+			}
+
+			// Exhausted rate limits are skipped up front so their jobs do not hold up other types
+			filter, err := p.manager.RateLimitedFilter(p.queues)
+			if err != nil {
+				logger.Logger.Error().Int("worker_id", id).Err(err).Msg("Error checking rate limits")
+				continue
+			}
+			filter.WorkerID = p.claimant
+
+			job, err := p.manager.GetPendingJob(filter)
 			if err != nil {
 				logger.Logger.Error().Int("worker_id", id).Err(err).Msg("Error getting pending job")
 				continue
 			}
 
 			if job != nil {
+				p.busy.Add(1)
 				p.processJob(id, job)
+				p.busy.Add(-1)
 			}
 		}
 	}
@@ -102,9 +266,31 @@ func (p *Pool) processJob(workerID int, job *interfaces.Job) {
 		Int("max_attempts", job.MaxAttempts).
 		Msg("Processing job")
 
-	result, err := p.processor.Process(job)
+	p.track(workerID, job, startTime)
+	ctx, progress := withProgress(withJobLogger(p.jobCtx, p.manager, job), p.manager, job)
+
+	// Handlers see the timeout of their job type through ctx and are expected to stop when it is done
+	timeout := p.manager.JobTimeout(job.Type)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result, err := p.processor.Process(ctx, job)
 	duration := time.Since(startTime).Seconds()
 	metrics.JobProcessingDuration.Observe(duration)
+	if !p.untrack(workerID) {
+		// Released by Shutdown, so the job belongs to whichever worker claims it next
+		logger.Logger.Info().Int("worker_id", workerID).Str("job_id", job.ID).Msg("Discarding outcome of released job")
+		return
+	}
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("job timed out after %v: %w", timeout, err)
+	}
+
+	// Written before the outcome, which the store refuses to overwrite with progress
+	progress.flush()
 
 	if err != nil {
 		logger.Logger.Error().
@@ -112,7 +298,12 @@ func (p *Pool) processJob(workerID int, job *interfaces.Job) {
 			Str("job_id", job.ID).
 			Err(err).
 			Msg("Job processing failed")
-		if updateErr := p.manager.UpdateJobFailed(job, err.Error()); updateErr != nil {
+		updateErr := p.manager.UpdateJobFailed(job, err.Error())
+		switch {
+		case errors.Is(updateErr, interfaces.ErrClaimLost):
+			// Released after this worker was declared dead, so the job belongs to its next run
+			logger.Logger.Warn().Int("worker_id", workerID).Str("job_id", job.ID).Msg("Discarding outcome of released job")
+		case updateErr != nil:
 			logger.Logger.Error().
 				Int("worker_id", workerID).
 				Str("job_id", job.ID).
@@ -122,7 +313,12 @@ func (p *Pool) processJob(workerID int, job *interfaces.Job) {
 		return
 	}
 
-	if err := p.manager.UpdateJobCompleted(job, result); err != nil {
+	err = p.manager.UpdateJobCompleted(job, result)
+	switch {
+	case errors.Is(err, interfaces.ErrClaimLost):
+		logger.Logger.Warn().Int("worker_id", workerID).Str("job_id", job.ID).Msg("Discarding outcome of released job")
+		return
+	case err != nil:
 		logger.Logger.Error().
 			Int("worker_id", workerID).
 			Str("job_id", job.ID).
@@ -141,7 +337,7 @@ func (p *Pool) processJob(workerID int, job *interfaces.Job) {
 type DefaultJobProcessor struct{}
 
 // Process implements JobProcessor interface
-func (d *DefaultJobProcessor) Process(job *interfaces.Job) (string, error) {
+func (d *DefaultJobProcessor) Process(ctx context.Context, job *interfaces.Job) (string, error) {
 	switch job.Type {
 	case "echo":
 		return fmt.Sprintf("Echo: %s", job.Payload), nil
@@ -154,9 +350,17 @@ func (d *DefaultJobProcessor) Process(job *interfaces.Job) (string, error) {
 		if err != nil {
 			n = big.NewInt(2)
 		}
-		sleepDuration := time.Duration(n.Int64()+1) * time.Second
-		logger.Logger.Debug().Str("job_id", job.ID).Dur("duration", sleepDuration).Msg("Slow job sleeping")
-		time.Sleep(sleepDuration)
+		seconds := int(n.Int64()) + 1
+		sleepDuration := time.Duration(seconds) * time.Second
+		Logger(ctx).Debug().Dur("duration", sleepDuration).Msg("Slow job sleeping")
+		for i := 1; i <= seconds; i++ {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(time.Second):
+			}
+			ReportProgress(ctx, i*100/seconds, fmt.Sprintf("slept %ds of %ds", i, seconds))
+		}
 		return fmt.Sprintf("Slow job completed after %v", sleepDuration), nil
 
 	case "fail":
@@ -0,0 +1,194 @@
+package worker
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/metrics"
+)
+
+// PoolSet runs one pool per PoolConfig and reshapes the pools when the configuration changes
+type PoolSet struct {
+	id        string // Worker process ID the pools claim jobs for
+	manager   *jobs.Manager
+	processor JobProcessor
+
+	mu          sync.Mutex
+	pools       map[string]*Pool       // Keyed by the pool's sorted queue list
+	autoscalers map[string]*Autoscaler // Autoscalers of the pools configured to autoscale, by the same key
+	retiring    map[*Pool]bool         // Pools removed by Apply that are still finishing their jobs
+	stopped     bool                   // Set by Shutdown, after which Apply starts nothing
+}
+
+// NewPoolSet creates an empty pool set with a new worker process ID; Apply starts its pools
+func NewPoolSet(manager *jobs.Manager, processor JobProcessor) *PoolSet {
+	return &PoolSet{
+		id:          uuid.New().String(),
+		manager:     manager,
+		processor:   processor,
+		pools:       make(map[string]*Pool),
+		autoscalers: make(map[string]*Autoscaler),
+		retiring:    make(map[*Pool]bool),
+	}
+}
+
+// ID returns the worker process ID recorded on the jobs the pools claim
+func (s *PoolSet) ID() string {
+	return s.id
+}
+
+// poolKey identifies a pool by the queues it serves, whatever order they were listed in
+func poolKey(queues []string) string {
+	sorted := slices.Clone(queues)
+	slices.Sort(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// Apply makes the running pools match configs: pools serving the same queues are resized,
+// new ones are started and pools no longer configured are stopped once their in-flight jobs finish.
+// Autoscaled pools start at their minimum size and are resized by an autoscaler following policy.
+func (s *PoolSet) Apply(configs []PoolConfig, pollInterval time.Duration, policy AutoscalePolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return
+	}
+
+	wanted := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		key := poolKey(config.Queues)
+		wanted[key] = true
+
+		pool, ok := s.pools[key]
+		if !ok {
+			pool = NewPool(s.manager, s.processor, config.WorkerCount, config.Queues...)
+			pool.claimant = s.id
+			pool.SetPollInterval(pollInterval)
+			pool.Start()
+			s.pools[key] = pool
+		}
+		pool.SetPollInterval(pollInterval)
+
+		autoscaler, autoscaled := s.autoscalers[key]
+		switch {
+		case config.Autoscaled() && autoscaled:
+			autoscaler.Update(config.WorkerCount, config.MaxWorkers, policy)
+		case config.Autoscaled():
+			autoscaler = NewAutoscaler(pool, s.manager, config.WorkerCount, config.MaxWorkers, policy)
+			autoscaler.Start()
+			s.autoscalers[key] = autoscaler
+		default:
+			if autoscaled {
+				autoscaler.Stop()
+				delete(s.autoscalers, key)
+			}
+			pool.Resize(config.WorkerCount)
+			metrics.WorkerPoolTargetSize.WithLabelValues(poolLabel(config.Queues)).Set(float64(config.WorkerCount))
+		}
+	}
+
+	for key, pool := range s.pools {
+		if !wanted[key] {
+			s.forget(key)
+			s.retiring[pool] = true
+			go func() {
+				pool.Stop()
+				s.mu.Lock()
+				delete(s.retiring, pool)
+				s.mu.Unlock()
+			}()
+		}
+	}
+}
+
+// Shutdown stops every pool, including those still retiring, giving their in-flight jobs until ctx is done to finish.
+// Jobs still running then are released back to the queue and ctx's error is returned.
+// The pools drain as retiring pools, so Status keeps reporting their jobs meanwhile.
+func (s *PoolSet) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopped = true
+	for key, pool := range s.pools {
+		s.forget(key)
+		s.retiring[pool] = true
+	}
+	pools := make([]*Pool, 0, len(s.retiring))
+	for pool := range s.retiring {
+		pools = append(pools, pool)
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, pool := range pools {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pool.Shutdown(ctx)
+
+			s.mu.Lock()
+			delete(s.retiring, pool)
+			s.mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// Pools only give up on their jobs once ctx is done
+	return ctx.Err()
+}
+
+// Status returns how many workers the set runs, the queues they claim from, nil meaning every queue,
+// and the jobs its workers are running, including those of pools still retiring
+func (s *PoolSet) Status() (int, []string, []*interfaces.WorkerJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	concurrency := 0
+	var queues []string
+	allQueues := false
+	for _, pool := range s.pools {
+		concurrency += pool.Size()
+		if len(pool.Queues()) == 0 {
+			allQueues = true
+		}
+		queues = append(queues, pool.Queues()...)
+	}
+	if allQueues {
+		queues = nil
+	} else {
+		slices.Sort(queues)
+		queues = slices.Compact(queues)
+	}
+
+	var running []*interfaces.WorkerJob
+	for _, pool := range s.pools {
+		running = append(running, pool.RunningJobs()...)
+	}
+	for pool := range s.retiring {
+		running = append(running, pool.RunningJobs()...)
+	}
+	slices.SortFunc(running, func(a, b *interfaces.WorkerJob) int {
+		if a.Pool != b.Pool {
+			return strings.Compare(a.Pool, b.Pool)
+		}
+		return a.Worker - b.Worker
+	})
+
+	return concurrency, queues, running
+}
+
+// forget removes a pool from the set, stopping its autoscaler; the caller stops the pool itself
+func (s *PoolSet) forget(key string) {
+	if autoscaler, ok := s.autoscalers[key]; ok {
+		autoscaler.Stop()
+		delete(s.autoscalers, key)
+	}
+	metrics.WorkerPoolTargetSize.DeleteLabelValues(poolLabel(s.pools[key].Queues()))
+	delete(s.pools, key)
+}
@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+// progressInterval is the minimum time between two progress writes for the same job
+const progressInterval = time.Second
+
+type progressKey struct{}
+
+// progressReporter collects the progress a handler reports for one job and writes it at most once per interval
+type progressReporter struct {
+	mu        sync.Mutex
+	manager   *jobs.Manager
+	job       *interfaces.Job
+	lastWrite time.Time
+	dirty     bool // Set when the job holds progress that has not been written yet
+}
+
+// withProgress returns a context through which the handler of job can report progress
+func withProgress(ctx context.Context, manager *jobs.Manager, job *interfaces.Job) (context.Context, *progressReporter) {
+	r := &progressReporter{manager: manager, job: job}
+	return context.WithValue(ctx, progressKey{}, r), r
+}
+
+// ReportProgress records how far the job being processed has come, as a percentage from 0 to 100
+// with an optional message. It does nothing when ctx does not belong to a job.
+func ReportProgress(ctx context.Context, percent int, message string) {
+	r, ok := ctx.Value(progressKey{}).(*progressReporter)
+	if !ok {
+		return
+	}
+
+	percent = max(0, min(percent, 100))
+	r.update(func(job *interfaces.Job) {
+		job.Progress = percent
+		job.ProgressMessage = message
+	})
+}
+
+// SaveCheckpoint stores an opaque checkpoint for the job being processed. If the job fails,
+// the checkpoint is handed back in Job.Checkpoint on the next attempt so the handler can resume.
+// It does nothing when ctx does not belong to a job.
+func SaveCheckpoint(ctx context.Context, checkpoint string) {
+	r, ok := ctx.Value(progressKey{}).(*progressReporter)
+	if !ok {
+		return
+	}
+
+	r.update(func(job *interfaces.Job) {
+		job.Checkpoint = checkpoint
+	})
+}
+
+// update applies a change to the job and writes it unless the last write was too recent
+func (r *progressReporter) update(change func(job *interfaces.Job)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	change(r.job)
+	r.dirty = true
+	if time.Since(r.lastWrite) >= progressInterval {
+		r.write()
+	}
+}
+
+// flush writes any progress held back by the throttle
+func (r *progressReporter) flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.dirty {
+		r.write()
+	}
+}
+
+// write stores the job's progress; callers must hold r.mu
+func (r *progressReporter) write() {
+	r.lastWrite = time.Now()
+	r.dirty = false
+
+	if err := r.manager.UpdateJobProgress(r.job); err != nil {
+		logger.WithJobID(r.job.ID).Error().Err(err).Msg("Failed to update job progress")
+	}
+}
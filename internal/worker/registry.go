@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/logger"
+)
+
+// Registry keeps a worker service process in the worker registry, sending heartbeats with the jobs
+// its pools are running. Each heartbeat also looks for other processes that stopped sending theirs
+// and expires the jobs that passed their deadline before they could start.
+type Registry struct {
+	manager *jobs.Manager
+	pools   *PoolSet
+	worker  *interfaces.Worker // Only used by Start and then the heartbeat goroutine
+
+	mu       sync.Mutex
+	interval time.Duration
+	timeout  time.Duration // How long after a heartbeat the process is declared dead without another
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewRegistry creates the registry entry of this process running pools, under the ID the pools claim jobs with; Start registers it
+func NewRegistry(manager *jobs.Manager, pools *PoolSet, version string, interval, timeout time.Duration) *Registry {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &Registry{
+		manager: manager,
+		pools:   pools,
+		worker: &interfaces.Worker{
+			ID:        pools.ID(),
+			Hostname:  hostname,
+			PID:       os.Getpid(),
+			Version:   version,
+			StartedAt: time.Now(),
+		},
+		interval: interval,
+		timeout:  timeout,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// ID returns the ID the process is registered under
+func (r *Registry) ID() string {
+	return r.worker.ID
+}
+
+// SetHeartbeat changes how often heartbeats are sent and how long the process may go without one,
+// taking effect at the next heartbeat
+func (r *Registry) SetHeartbeat(interval, timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interval = interval
+	r.timeout = timeout
+}
+
+func (r *Registry) heartbeatSettings() (time.Duration, time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.interval, r.timeout
+}
+
+// Start registers the process and sends its heartbeats until Stop is called
+func (r *Registry) Start() error {
+	_, timeout := r.heartbeatSettings()
+	r.worker.Concurrency, r.worker.Queues, r.worker.Running = r.pools.Status()
+	if err := r.manager.RegisterWorker(r.worker, timeout); err != nil {
+		return err
+	}
+	logger.Logger.Info().Str("worker", r.worker.ID).Str("hostname", r.worker.Hostname).Int("pid", r.worker.PID).Msg("Worker registered")
+
+	go r.run()
+	return nil
+}
+
+func (r *Registry) run() {
+	defer close(r.done)
+
+	interval, _ := r.heartbeatSettings()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.heartbeat()
+			r.manager.DetectDeadWorkers()
+			r.manager.ExpireJobs()
+
+			if current, _ := r.heartbeatSettings(); current != interval {
+				interval = current
+				ticker.Reset(interval)
+			}
+		}
+	}
+}
+
+// heartbeat records what the pools are running, registering the process again if it was declared dead meanwhile
+func (r *Registry) heartbeat() {
+	_, timeout := r.heartbeatSettings()
+	r.worker.Concurrency, r.worker.Queues, r.worker.Running = r.pools.Status()
+
+	alive, err := r.manager.WorkerHeartbeat(r.worker, timeout)
+	if err != nil {
+		logger.Logger.Error().Str("worker", r.worker.ID).Err(err).Msg("Failed to send worker heartbeat")
+		return
+	}
+	if alive {
+		return
+	}
+
+	logger.Logger.Warn().Str("worker", r.worker.ID).Msg("Worker was declared dead after missing its heartbeats, registering again")
+	if err := r.manager.RegisterWorker(r.worker, timeout); err != nil {
+		logger.Logger.Error().Str("worker", r.worker.ID).Err(err).Msg("Failed to register worker again")
+	}
+}
+
+// Stop stops the heartbeats and records that the process stopped cleanly
+func (r *Registry) Stop() {
+	close(r.stop)
+	<-r.done
+
+	if err := r.manager.StopWorker(r.worker.ID); err != nil {
+		logger.Logger.Error().Str("worker", r.worker.ID).Err(err).Msg("Failed to record that the worker stopped")
+		return
+	}
+	logger.Logger.Info().Str("worker", r.worker.ID).Msg("Worker deregistered")
+}
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE batches (
+    id VARCHAR(36) PRIMARY KEY,
+    total INTEGER NOT NULL,
+    succeeded INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    on_success_type VARCHAR(255),
+    on_success_payload TEXT,
+    on_failure_type VARCHAR(255),
+    on_failure_payload TEXT,
+    callback_job_id VARCHAR(36),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE jobs ADD COLUMN batch_id VARCHAR(36) REFERENCES batches (id);
+
+-- Index for looking up the members of a batch
+CREATE INDEX idx_jobs_batch_id ON jobs (batch_id) WHERE batch_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs DROP COLUMN batch_id;
+DROP TABLE batches;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE job_dependencies (
+    job_id VARCHAR(36) NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    depends_on_id VARCHAR(36) NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    PRIMARY KEY (job_id, depends_on_id)
+);
+
+-- Index for finding the dependents of a finished job
+CREATE INDEX idx_job_dependencies_depends_on_id ON job_dependencies (depends_on_id);
+
+ALTER TABLE jobs ADD COLUMN dependency_policy VARCHAR(20) NOT NULL DEFAULT 'fail';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs DROP COLUMN dependency_policy;
+DROP TABLE job_dependencies;
+-- +goose StatementEnd
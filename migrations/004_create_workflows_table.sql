@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE workflows (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'running',
+    output_step VARCHAR(255) NOT NULL,
+    output TEXT,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE jobs ADD COLUMN workflow_id VARCHAR(36) REFERENCES workflows (id);
+
+CREATE TABLE workflow_steps (
+    workflow_id VARCHAR(36) NOT NULL REFERENCES workflows (id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    position INTEGER NOT NULL,
+    job_id VARCHAR(36) NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    depends_on TEXT[] NOT NULL DEFAULT '{}',
+    input_from VARCHAR(255),
+    PRIMARY KEY (workflow_id, name)
+);
+
+-- Index for finding the step a finished job belongs to
+CREATE UNIQUE INDEX idx_workflow_steps_job_id ON workflow_steps (job_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE workflow_steps;
+ALTER TABLE jobs DROP COLUMN workflow_id;
+DROP TABLE workflows;
+-- +goose StatementEnd
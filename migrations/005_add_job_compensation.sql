@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE jobs
+    ADD COLUMN compensation_type VARCHAR(255),
+    ADD COLUMN compensation_payload TEXT,
+    ADD COLUMN compensation_status VARCHAR(50),
+    ADD COLUMN compensation_job_id VARCHAR(36),
+    ADD COLUMN compensates_job_id VARCHAR(36) REFERENCES jobs (id) ON DELETE SET NULL;
+
+-- Index for listing jobs that have been or are being rolled back
+CREATE INDEX idx_jobs_compensation_status ON jobs (compensation_status) WHERE compensation_status IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs
+    DROP COLUMN compensates_job_id,
+    DROP COLUMN compensation_job_id,
+    DROP COLUMN compensation_status,
+    DROP COLUMN compensation_payload,
+    DROP COLUMN compensation_type;
+-- +goose StatementEnd
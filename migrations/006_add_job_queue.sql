@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE jobs ADD COLUMN queue VARCHAR(100) NOT NULL DEFAULT 'default';
+
+-- Index for claiming the oldest runnable job of a set of queues
+CREATE INDEX idx_jobs_queue_status_created_at ON jobs (queue, status, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_queue_status_created_at;
+ALTER TABLE jobs DROP COLUMN queue;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE queue_state (
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('queue', 'type')),
+    name VARCHAR(255) NOT NULL,
+    state VARCHAR(20) NOT NULL CHECK (state IN ('paused', 'draining')),
+    reason TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (kind, name)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS queue_state;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE job_type_limits (
+    type VARCHAR(255) PRIMARY KEY,
+    max_concurrency INTEGER NOT NULL CHECK (max_concurrency > 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Index for counting the running jobs of a type when claiming
+CREATE INDEX idx_jobs_type_processing ON jobs (type) WHERE status = 'processing';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_type_processing;
+DROP TABLE IF EXISTS job_type_limits;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE rate_limits (
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('queue', 'type')),
+    name VARCHAR(255) NOT NULL,
+    limit_count INTEGER NOT NULL CHECK (limit_count > 0),
+    period_seconds INTEGER NOT NULL CHECK (period_seconds > 0),
+    burst INTEGER NOT NULL CHECK (burst > 0),
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (kind, name)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rate_limits;
+-- +goose StatementEnd
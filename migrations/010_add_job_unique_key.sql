@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE jobs
+    ADD COLUMN unique_key VARCHAR(255),
+    ADD COLUMN unique_scope VARCHAR(30) CHECK (unique_scope IN ('pending', 'pending_or_running', 'window')),
+    ADD COLUMN unique_until TIMESTAMP WITH TIME ZONE;
+
+-- At most one job per key may wait to run; retrying counts as started so a retry never collides
+CREATE UNIQUE INDEX idx_jobs_unique_pending ON jobs (unique_key)
+    WHERE unique_scope = 'pending' AND status IN ('pending', 'blocked');
+
+-- At most one job per key may wait to run or be running
+CREATE UNIQUE INDEX idx_jobs_unique_pending_or_running ON jobs (unique_key)
+    WHERE unique_scope = 'pending_or_running' AND status IN ('pending', 'blocked', 'processing', 'retrying');
+
+-- Index for finding the job holding a key, including time window keys checked at submission
+CREATE INDEX idx_jobs_unique_key ON jobs (unique_key, unique_scope) WHERE unique_key IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_unique_key;
+DROP INDEX IF EXISTS idx_jobs_unique_pending_or_running;
+DROP INDEX IF EXISTS idx_jobs_unique_pending;
+ALTER TABLE jobs
+    DROP COLUMN unique_until,
+    DROP COLUMN unique_scope,
+    DROP COLUMN unique_key;
+-- +goose StatementEnd
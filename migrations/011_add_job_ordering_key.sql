@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE jobs
+    ADD COLUMN ordering_key VARCHAR(255),
+    ADD COLUMN sequence BIGSERIAL;
+
+-- Index for finding unfinished jobs submitted earlier with the same ordering key
+CREATE INDEX idx_jobs_ordering_key_sequence ON jobs (ordering_key, sequence)
+    WHERE ordering_key IS NOT NULL AND status IN ('pending', 'blocked', 'processing', 'retrying');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_ordering_key_sequence;
+ALTER TABLE jobs
+    DROP COLUMN sequence,
+    DROP COLUMN ordering_key;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE jobs ADD COLUMN expires_at TIMESTAMP WITH TIME ZONE;
+
+-- Index for finding jobs that expired before they could start
+CREATE INDEX idx_jobs_expires_at ON jobs (expires_at)
+    WHERE expires_at IS NOT NULL AND status IN ('pending', 'blocked', 'retrying');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_expires_at;
+ALTER TABLE jobs DROP COLUMN expires_at;
+-- +goose StatementEnd
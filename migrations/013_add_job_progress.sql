@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE jobs ADD COLUMN progress INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN progress_message TEXT;
+ALTER TABLE jobs ADD COLUMN checkpoint TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs DROP COLUMN checkpoint;
+ALTER TABLE jobs DROP COLUMN progress_message;
+ALTER TABLE jobs DROP COLUMN progress;
+-- +goose StatementEnd
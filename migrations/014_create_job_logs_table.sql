@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE job_logs (
+    id BIGSERIAL PRIMARY KEY,
+    job_id VARCHAR(36) NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    level VARCHAR(10) NOT NULL,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Index for reading a job's log lines in order, and for following them from the last line seen
+CREATE INDEX idx_job_logs_job_id ON job_logs (job_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE job_logs;
+-- +goose StatementEnd
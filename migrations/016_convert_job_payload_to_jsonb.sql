@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Payloads that are not valid JSON are kept as JSON strings, which read back as the original text
+CREATE FUNCTION job_payload_to_jsonb(payload TEXT) RETURNS JSONB AS $$
+BEGIN
+    RETURN payload::jsonb;
+EXCEPTION WHEN others THEN
+    RETURN to_jsonb(payload);
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+ALTER TABLE jobs ALTER COLUMN payload TYPE JSONB USING job_payload_to_jsonb(payload);
+
+DROP FUNCTION job_payload_to_jsonb(TEXT);
+
+-- Index for querying jobs by payload fields, e.g. payload @> '{"user_id": 42}'
+CREATE INDEX idx_jobs_payload ON jobs USING GIN (payload jsonb_path_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_payload;
+ALTER TABLE jobs ALTER COLUMN payload TYPE TEXT USING payload #>> '{}';
+-- +goose StatementEnd
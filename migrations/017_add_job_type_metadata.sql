@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE job_types ADD COLUMN description TEXT;
+ALTER TABLE job_types ADD COLUMN default_queue VARCHAR(100);
+ALTER TABLE job_types ADD COLUMN default_max_attempts INTEGER;
+ALTER TABLE job_types ADD COLUMN retry_base_delay_seconds INTEGER;
+ALTER TABLE job_types ADD COLUMN retry_max_delay_seconds INTEGER;
+ALTER TABLE job_types ADD COLUMN timeout_seconds INTEGER;
+ALTER TABLE job_types ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE job_types ADD COLUMN owner_team VARCHAR(255);
+
+-- Higher priority jobs are claimed first
+ALTER TABLE jobs ADD COLUMN priority INTEGER NOT NULL DEFAULT 0;
+
+-- Index for claiming the most important runnable job
+CREATE INDEX idx_jobs_priority_created_at ON jobs (priority DESC, created_at)
+    WHERE status IN ('pending', 'retrying');
+
+-- The types handled by the default job processor
+INSERT INTO job_types (name, description) VALUES
+    ('echo', 'Echoes the payload back'),
+    ('uppercase', 'Echoes the payload back with an UPPERCASE prefix'),
+    ('slow', 'Sleeps for one to five seconds, reporting progress'),
+    ('fail', 'Always fails, for exercising retries')
+ON CONFLICT (name) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM job_types WHERE name IN ('echo', 'uppercase', 'slow', 'fail') AND payload_schema IS NULL;
+DROP INDEX IF EXISTS idx_jobs_priority_created_at;
+ALTER TABLE jobs DROP COLUMN priority;
+ALTER TABLE job_types DROP COLUMN owner_team;
+ALTER TABLE job_types DROP COLUMN priority;
+ALTER TABLE job_types DROP COLUMN timeout_seconds;
+ALTER TABLE job_types DROP COLUMN retry_max_delay_seconds;
+ALTER TABLE job_types DROP COLUMN retry_base_delay_seconds;
+ALTER TABLE job_types DROP COLUMN default_max_attempts;
+ALTER TABLE job_types DROP COLUMN default_queue;
+ALTER TABLE job_types DROP COLUMN description;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE workers (
+    id VARCHAR(36) PRIMARY KEY,
+    hostname VARCHAR(255) NOT NULL,
+    pid INTEGER NOT NULL,
+    version VARCHAR(100) NOT NULL,
+    queues TEXT[] NOT NULL DEFAULT '{}',
+    concurrency INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('active', 'stopped', 'dead')),
+    running JSONB NOT NULL DEFAULT '[]',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_heartbeat_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    dead_after TIMESTAMP WITH TIME ZONE NOT NULL,
+    stopped_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Index for finding active workers that missed their heartbeats
+CREATE INDEX idx_workers_dead_after ON workers (dead_after) WHERE status = 'active';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE workers;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The worker process that claimed the job last; outcomes from any other process are refused
+ALTER TABLE jobs ADD COLUMN claimed_by VARCHAR(36);
+
+-- Index for finding the jobs a dead worker was running
+CREATE INDEX idx_jobs_claimed_by ON jobs (claimed_by) WHERE status = 'processing';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_claimed_by;
+ALTER TABLE jobs DROP COLUMN claimed_by;
+-- +goose StatementEnd
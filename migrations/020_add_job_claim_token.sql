@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Identifies the claim a processing job is running under. A worker process can claim a job again after it was
+-- released from under it, so outcomes are checked against the claim rather than claimed_by.
+ALTER TABLE jobs ADD COLUMN claim_token VARCHAR(36);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs DROP COLUMN claim_token;
+-- +goose StatementEnd
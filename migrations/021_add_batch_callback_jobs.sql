@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The callback jobs are stored blocked along with the batch; the one matching the outcome is released
+-- in the transaction that finishes the batch and the other is dropped
+ALTER TABLE batches ADD COLUMN on_success_job_id VARCHAR(36);
+ALTER TABLE batches ADD COLUMN on_failure_job_id VARCHAR(36);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE batches DROP COLUMN on_failure_job_id;
+ALTER TABLE batches DROP COLUMN on_success_job_id;
+-- +goose StatementEnd
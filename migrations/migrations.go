@@ -0,0 +1,9 @@
+// Package migrations embeds the database schema migrations so every binary carries its own copy
+package migrations
+
+import "embed"
+
+// FS holds the goose migration files
+//
+//go:embed *.sql
+var FS embed.FS
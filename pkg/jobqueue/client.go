@@ -0,0 +1,120 @@
+package jobqueue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client submits jobs to the queue and reads them back
+type Client interface {
+	// Submit enqueues a job and returns it as stored
+	Submit(ctx context.Context, req JobRequest) (*Job, error)
+	// GetJob returns the current state of a job, or ErrNotFound
+	GetJob(ctx context.Context, id string) (*Job, error)
+	// Close releases the client's connections
+	Close() error
+}
+
+// HTTPClient talks to the REST API of the API service
+type HTTPClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewHTTPClient creates a client for the API service at baseURL, such as "http://localhost:8080"
+func NewHTTPClient(baseURL string) *HTTPClient {
+	return &HTTPClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Submit enqueues a job through POST /jobs
+func (c *HTTPClient) Submit(ctx context.Context, req JobRequest) (*Job, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	job := &Job{}
+	if err := c.do(ctx, http.MethodPost, "/jobs", body, job); err != nil {
+		var payloadErr *PayloadError
+		if errors.As(err, &payloadErr) {
+			payloadErr.Type = req.Type
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJob reads a job through GET /jobs/{id}
+func (c *HTTPClient) GetJob(ctx context.Context, id string) (*Job, error) {
+	job := &Job{}
+	if err := c.do(ctx, http.MethodGet, "/jobs/"+url.PathEscape(id), nil, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Close implements Client; the HTTP client holds no connections of its own
+func (c *HTTPClient) Close() error {
+	return nil
+}
+
+// do sends a request to the API service and decodes a successful response into out
+func (c *HTTPClient) do(ctx context.Context, method, path string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return responseError(resp)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// responseError turns an error response of the API service into the matching error
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	message := strings.TrimSpace(string(body))
+
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		var payloadErr struct {
+			Fields []FieldError `json:"fields"`
+		}
+		if json.Unmarshal(body, &payloadErr) == nil && len(payloadErr.Fields) > 0 {
+			return &PayloadError{Fields: payloadErr.Fields}
+		}
+		return fmt.Errorf("%w: %s", ErrInvalidJob, message)
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: %s", ErrNotFound, message)
+	case http.StatusConflict:
+		return fmt.Errorf("%w: %s", ErrDuplicateJob, message)
+	case http.StatusServiceUnavailable:
+		return fmt.Errorf("%w: %s", ErrQueueDraining, message)
+	}
+	return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, message)
+}
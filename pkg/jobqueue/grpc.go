@@ -0,0 +1,44 @@
+package jobqueue
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mtr002/Job-Queue/internal/grpc"
+)
+
+// GRPCClient talks to the worker service over gRPC
+type GRPCClient struct {
+	client *grpc.Client
+}
+
+// NewGRPCClient connects to the worker service at addr, such as "localhost:8081"
+func NewGRPCClient(addr string) (*GRPCClient, error) {
+	client, err := grpc.NewClient(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &GRPCClient{client: client}, nil
+}
+
+// Submit enqueues a job through the SubmitJob RPC
+func (c *GRPCClient) Submit(ctx context.Context, req JobRequest) (*Job, error) {
+	return c.client.SubmitJobContext(ctx, req)
+}
+
+// GetJob reads a job through the GetJobStatus RPC
+func (c *GRPCClient) GetJob(ctx context.Context, id string) (*Job, error) {
+	job, err := c.client.GetJobStatusContext(ctx, id)
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, status.Convert(err).Message())
+	}
+	return job, err
+}
+
+// Close closes the connection to the worker service
+func (c *GRPCClient) Close() error {
+	return c.client.Close()
+}
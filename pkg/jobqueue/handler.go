@@ -0,0 +1,90 @@
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Handler processes the jobs of one type and returns their result
+type Handler interface {
+	Process(ctx context.Context, job *Job) (string, error)
+}
+
+// HandlerFunc adapts a function to a Handler
+type HandlerFunc func(ctx context.Context, job *Job) (string, error)
+
+// Process calls f
+func (f HandlerFunc) Process(ctx context.Context, job *Job) (string, error) {
+	return f(ctx, job)
+}
+
+// Mux is a Handler that routes every job to the handler registered for its type
+type Mux struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewMux creates an empty Mux
+func NewMux() *Mux {
+	return &Mux{handlers: make(map[string]Handler)}
+}
+
+// Handle registers the handler for a job type, replacing any earlier one
+func (m *Mux) Handle(jobType string, handler Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[jobType] = handler
+}
+
+// HandleFunc registers a function as the handler for a job type
+func (m *Mux) HandleFunc(jobType string, fn func(ctx context.Context, job *Job) (string, error)) {
+	m.Handle(jobType, HandlerFunc(fn))
+}
+
+// Types lists the job types with a registered handler
+func (m *Mux) Types() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	types := make([]string, 0, len(m.handlers))
+	for jobType := range m.handlers {
+		types = append(types, jobType)
+	}
+	return types
+}
+
+// Process runs the handler registered for the job's type
+func (m *Mux) Process(ctx context.Context, job *Job) (string, error) {
+	m.mu.RLock()
+	handler, ok := m.handlers[job.Type]
+	m.mu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("no handler registered for job type %s", job.Type)
+	}
+	return handler.Process(ctx, job)
+}
+
+// HandleTyped registers a handler whose payload is decoded from JSON into P
+// and whose result R is encoded back to JSON as the job's result
+func HandleTyped[P, R any](mux *Mux, jobType string, fn func(ctx context.Context, job *Job, payload P) (R, error)) {
+	mux.HandleFunc(jobType, func(ctx context.Context, job *Job) (string, error) {
+		var payload P
+		if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+			return "", fmt.Errorf("failed to decode %s payload: %w", jobType, err)
+		}
+
+		result, err := fn(ctx, job, payload)
+		if err != nil {
+			return "", err
+		}
+
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode %s result: %w", jobType, err)
+		}
+		return string(encoded), nil
+	})
+}
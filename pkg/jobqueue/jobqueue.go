@@ -0,0 +1,96 @@
+// Package jobqueue is the public API of the job queue: a Client for submitting jobs
+// over HTTP or gRPC, and a Worker that runs typed handlers for the jobs it claims.
+//
+// Submitting a job with a struct payload:
+//
+//	client := jobqueue.NewHTTPClient("http://localhost:8080")
+//	req, err := jobqueue.NewRequest("send_email", Email{To: "a@example.com"})
+//	job, err := client.Submit(ctx, req)
+//
+// Handling it:
+//
+//	mux := jobqueue.NewMux()
+//	jobqueue.HandleTyped(mux, "send_email", func(ctx context.Context, job *jobqueue.Job, email Email) (Receipt, error) {
+//		return send(ctx, email)
+//	})
+//	w := jobqueue.NewWorker(database, mux, jobqueue.WorkerOptions{Concurrency: 4})
+//	if err := w.Start(); err != nil {
+//		return err
+//	}
+//	defer w.Stop()
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/mtr002/Job-Queue/internal/interfaces"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/worker"
+)
+
+type (
+	// Job is a job in the queue
+	Job = interfaces.Job
+	// JobStatus is the current state of a job
+	JobStatus = interfaces.JobStatus
+	// JobRequest describes a job to submit
+	JobRequest = jobs.JobRequest
+	// PayloadError lists the fields of a payload that do not match the schema of its job type
+	PayloadError = jobs.PayloadError
+	// FieldError describes one field of a PayloadError
+	FieldError = jobs.FieldError
+)
+
+const (
+	StatusPending         = interfaces.StatusPending
+	StatusBlocked         = interfaces.StatusBlocked
+	StatusProcessing      = interfaces.StatusProcessing
+	StatusRetrying        = interfaces.StatusRetrying
+	StatusCompleted       = interfaces.StatusCompleted
+	StatusPermanentFailed = interfaces.StatusPermanentFailed
+	StatusSkipped         = interfaces.StatusSkipped
+	StatusExpired         = interfaces.StatusExpired
+	StatusCancelled       = interfaces.StatusCancelled
+)
+
+var (
+	// ErrNotFound is returned when a job does not exist
+	ErrNotFound = errors.New("job not found")
+	// ErrQueueDraining is returned when a job is submitted to a draining queue or job type
+	ErrQueueDraining = jobs.ErrQueueDraining
+	// ErrDuplicateJob is returned when a job's unique key is held and the request asked for a conflict
+	ErrDuplicateJob = jobs.ErrDuplicateJob
+	// ErrInvalidPayload is wrapped by every PayloadError
+	ErrInvalidPayload = jobs.ErrInvalidPayload
+	// ErrInvalidJob is returned when a job request fails validation other than of its payload
+	ErrInvalidJob = jobs.ErrInvalidJob
+)
+
+// NewRequest builds a request for a job whose payload is v encoded as JSON
+func NewRequest[P any](jobType string, v P) (JobRequest, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return JobRequest{}, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	return JobRequest{Type: jobType, Payload: string(payload)}, nil
+}
+
+// ReportProgress records how far the job handled with ctx has come, as a percentage from 0 to 100
+func ReportProgress(ctx context.Context, percent int, message string) {
+	worker.ReportProgress(ctx, percent, message)
+}
+
+// SaveCheckpoint stores a checkpoint for the job handled with ctx, handed back in Job.Checkpoint when it is retried
+func SaveCheckpoint(ctx context.Context, checkpoint string) {
+	worker.SaveCheckpoint(ctx, checkpoint)
+}
+
+// Logger returns the logger for the job handled with ctx, whose lines are stored with the job
+func Logger(ctx context.Context) *zerolog.Logger {
+	return worker.Logger(ctx)
+}
@@ -0,0 +1,91 @@
+package jobqueue
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mtr002/Job-Queue/internal/db"
+	"github.com/mtr002/Job-Queue/internal/jobs"
+	"github.com/mtr002/Job-Queue/internal/worker"
+)
+
+// Handlers run inside the worker pool, so they must satisfy its processor interface
+var _ worker.JobProcessor = Handler(nil)
+
+// workerVersion is the version SDK workers report in the worker registry
+const workerVersion = "sdk"
+
+// WorkerOptions configures a Worker
+type WorkerOptions struct {
+	// Concurrency is how many jobs run at once, 1 if unset
+	Concurrency int
+	// Queues limits the worker to these queues; it claims from every queue if empty
+	Queues []string
+	// DefaultMaxAttempts applies to follow-up jobs the worker submits, such as compensations,
+	// whose request and job type set none; 3 if unset
+	DefaultMaxAttempts int
+	// HeartbeatInterval is how often the worker reports to the worker registry, 10s if unset
+	HeartbeatInterval time.Duration
+	// HeartbeatTimeout is how long without a heartbeat before the worker is declared dead
+	// and its jobs are released, 30s if unset
+	HeartbeatTimeout time.Duration
+}
+
+// Worker claims jobs from the queue database and runs them with a Handler.
+// It registers in the worker registry like the worker service, so its jobs are released if it dies.
+type Worker struct {
+	pools    *worker.PoolSet
+	registry *worker.Registry
+	config   worker.PoolConfig
+}
+
+// NewWorker creates a worker that claims jobs from the queue database and runs them with handler.
+// The database must already be migrated by one of the queue's services.
+func NewWorker(database *sql.DB, handler Handler, opts WorkerOptions) *Worker {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	interval := opts.HeartbeatInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	timeout := opts.HeartbeatTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	manager := jobs.NewManager(db.NewStore(database), opts.DefaultMaxAttempts)
+	pools := worker.NewPoolSet(manager, handler)
+	return &Worker{
+		pools:    pools,
+		registry: worker.NewRegistry(manager, pools, workerVersion, interval, timeout),
+		config:   worker.PoolConfig{Queues: opts.Queues, WorkerCount: concurrency},
+	}
+}
+
+// Start registers the worker and begins claiming and running jobs in the background.
+// Nothing is claimed if the worker cannot register.
+func (w *Worker) Start() error {
+	if err := w.registry.Start(); err != nil {
+		return err
+	}
+	w.pools.Apply([]worker.PoolConfig{w.config}, time.Second, worker.DefaultAutoscalePolicy)
+	return nil
+}
+
+// Stop stops claiming new jobs, waits for the running ones to finish and deregisters the worker
+func (w *Worker) Stop() {
+	w.Shutdown(context.Background())
+}
+
+// Shutdown stops claiming new jobs and gives the running ones until ctx is done to finish.
+// Jobs still running then are released back to the queue for another worker and ctx's error is returned;
+// their handlers see their context cancelled. The worker is deregistered either way.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	err := w.pools.Shutdown(ctx)
+	// Heartbeats go on while the pools drain so the worker is not declared dead with jobs it is still finishing
+	w.registry.Stop()
+	return err
+}
@@ -22,12 +22,24 @@ const (
 )
 
 type SubmitJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Payload       string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
-	MaxAttempts   int32                  `protobuf:"varint,3,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Type                string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Payload             string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	MaxAttempts         int32                  `protobuf:"varint,3,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	DependsOn           []string               `protobuf:"bytes,4,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	DependencyPolicy    string                 `protobuf:"bytes,5,opt,name=dependency_policy,json=dependencyPolicy,proto3" json:"dependency_policy,omitempty"`
+	CompensationType    string                 `protobuf:"bytes,6,opt,name=compensation_type,json=compensationType,proto3" json:"compensation_type,omitempty"`
+	CompensationPayload string                 `protobuf:"bytes,7,opt,name=compensation_payload,json=compensationPayload,proto3" json:"compensation_payload,omitempty"`
+	Queue               string                 `protobuf:"bytes,8,opt,name=queue,proto3" json:"queue,omitempty"`
+	UniqueKey           string                 `protobuf:"bytes,9,opt,name=unique_key,json=uniqueKey,proto3" json:"unique_key,omitempty"`
+	UniqueScope         string                 `protobuf:"bytes,10,opt,name=unique_scope,json=uniqueScope,proto3" json:"unique_scope,omitempty"`
+	UniqueWindow        string                 `protobuf:"bytes,11,opt,name=unique_window,json=uniqueWindow,proto3" json:"unique_window,omitempty"`
+	OnDuplicate         string                 `protobuf:"bytes,12,opt,name=on_duplicate,json=onDuplicate,proto3" json:"on_duplicate,omitempty"`
+	OrderingKey         string                 `protobuf:"bytes,13,opt,name=ordering_key,json=orderingKey,proto3" json:"ordering_key,omitempty"`
+	ExpiresAt           string                 `protobuf:"bytes,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Priority            *int32                 `protobuf:"varint,15,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *SubmitJobRequest) Reset() {
@@ -81,6 +93,90 @@ func (x *SubmitJobRequest) GetMaxAttempts() int32 {
 	return 0
 }
 
+func (x *SubmitJobRequest) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetDependencyPolicy() string {
+	if x != nil {
+		return x.DependencyPolicy
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetCompensationType() string {
+	if x != nil {
+		return x.CompensationType
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetCompensationPayload() string {
+	if x != nil {
+		return x.CompensationPayload
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetUniqueKey() string {
+	if x != nil {
+		return x.UniqueKey
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetUniqueScope() string {
+	if x != nil {
+		return x.UniqueScope
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetUniqueWindow() string {
+	if x != nil {
+		return x.UniqueWindow
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetOnDuplicate() string {
+	if x != nil {
+		return x.OnDuplicate
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetOrderingKey() string {
+	if x != nil {
+		return x.OrderingKey
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
 type SubmitJobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
@@ -141,6 +237,126 @@ func (x *SubmitJobResponse) GetCreatedAt() string {
 	return ""
 }
 
+type SubmitJobsResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobsResult) Reset() {
+	*x = SubmitJobsResult{}
+	mi := &file_proto_jobqueue_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobsResult) ProtoMessage() {}
+
+func (x *SubmitJobsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobsResult.ProtoReflect.Descriptor instead.
+func (*SubmitJobsResult) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubmitJobsResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SubmitJobsResult) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *SubmitJobsResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SubmitJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SubmitJobsResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Accepted      int32                  `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      int32                  `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobsResponse) Reset() {
+	*x = SubmitJobsResponse{}
+	mi := &file_proto_jobqueue_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobsResponse) ProtoMessage() {}
+
+func (x *SubmitJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobsResponse.ProtoReflect.Descriptor instead.
+func (*SubmitJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SubmitJobsResponse) GetResults() []*SubmitJobsResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SubmitJobsResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *SubmitJobsResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
 type GetJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
@@ -150,7 +366,7 @@ type GetJobRequest struct {
 
 func (x *GetJobRequest) Reset() {
 	*x = GetJobRequest{}
-	mi := &file_proto_jobqueue_proto_msgTypes[2]
+	mi := &file_proto_jobqueue_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -162,7 +378,7 @@ func (x *GetJobRequest) String() string {
 func (*GetJobRequest) ProtoMessage() {}
 
 func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jobqueue_proto_msgTypes[2]
+	mi := &file_proto_jobqueue_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -175,7 +391,7 @@ func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
 func (*GetJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_jobqueue_proto_rawDescGZIP(), []int{2}
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetJobRequest) GetJobId() string {
@@ -186,24 +402,34 @@ func (x *GetJobRequest) GetJobId() string {
 }
 
 type JobStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	Payload       string                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
-	Result        string                 `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
-	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
-	Attempts      int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
-	MaxAttempts   int32                  `protobuf:"varint,8,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	JobId              string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Type               string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Status             string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Payload            string                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Result             string                 `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	Error              string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts           int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	MaxAttempts        int32                  `protobuf:"varint,8,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	CreatedAt          string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CompensationStatus string                 `protobuf:"bytes,11,opt,name=compensation_status,json=compensationStatus,proto3" json:"compensation_status,omitempty"`
+	CompensationJobId  string                 `protobuf:"bytes,12,opt,name=compensation_job_id,json=compensationJobId,proto3" json:"compensation_job_id,omitempty"`
+	Queue              string                 `protobuf:"bytes,13,opt,name=queue,proto3" json:"queue,omitempty"`
+	UniqueKey          string                 `protobuf:"bytes,14,opt,name=unique_key,json=uniqueKey,proto3" json:"unique_key,omitempty"`
+	OrderingKey        string                 `protobuf:"bytes,15,opt,name=ordering_key,json=orderingKey,proto3" json:"ordering_key,omitempty"`
+	ExpiresAt          string                 `protobuf:"bytes,16,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Progress           int32                  `protobuf:"varint,17,opt,name=progress,proto3" json:"progress,omitempty"`
+	ProgressMessage    string                 `protobuf:"bytes,18,opt,name=progress_message,json=progressMessage,proto3" json:"progress_message,omitempty"`
+	Checkpoint         string                 `protobuf:"bytes,19,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	Priority           int32                  `protobuf:"varint,20,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *JobStatusResponse) Reset() {
 	*x = JobStatusResponse{}
-	mi := &file_proto_jobqueue_proto_msgTypes[3]
+	mi := &file_proto_jobqueue_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -215,7 +441,7 @@ func (x *JobStatusResponse) String() string {
 func (*JobStatusResponse) ProtoMessage() {}
 
 func (x *JobStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jobqueue_proto_msgTypes[3]
+	mi := &file_proto_jobqueue_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -228,7 +454,7 @@ func (x *JobStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobStatusResponse.ProtoReflect.Descriptor instead.
 func (*JobStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_jobqueue_proto_rawDescGZIP(), []int{3}
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *JobStatusResponse) GetJobId() string {
@@ -301,28 +527,99 @@ func (x *JobStatusResponse) GetUpdatedAt() string {
 	return ""
 }
 
-type ProcessJobRequest struct {
+func (x *JobStatusResponse) GetCompensationStatus() string {
+	if x != nil {
+		return x.CompensationStatus
+	}
+	return ""
+}
+
+func (x *JobStatusResponse) GetCompensationJobId() string {
+	if x != nil {
+		return x.CompensationJobId
+	}
+	return ""
+}
+
+func (x *JobStatusResponse) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *JobStatusResponse) GetUniqueKey() string {
+	if x != nil {
+		return x.UniqueKey
+	}
+	return ""
+}
+
+func (x *JobStatusResponse) GetOrderingKey() string {
+	if x != nil {
+		return x.OrderingKey
+	}
+	return ""
+}
+
+func (x *JobStatusResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *JobStatusResponse) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *JobStatusResponse) GetProgressMessage() string {
+	if x != nil {
+		return x.ProgressMessage
+	}
+	return ""
+}
+
+func (x *JobStatusResponse) GetCheckpoint() string {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return ""
+}
+
+func (x *JobStatusResponse) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type ExecuteJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Job           *SubmitJobRequest      `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	TimeoutMs     int64                  `protobuf:"varint,2,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProcessJobRequest) Reset() {
-	*x = ProcessJobRequest{}
-	mi := &file_proto_jobqueue_proto_msgTypes[4]
+func (x *ExecuteJobRequest) Reset() {
+	*x = ExecuteJobRequest{}
+	mi := &file_proto_jobqueue_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProcessJobRequest) String() string {
+func (x *ExecuteJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProcessJobRequest) ProtoMessage() {}
+func (*ExecuteJobRequest) ProtoMessage() {}
 
-func (x *ProcessJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jobqueue_proto_msgTypes[4]
+func (x *ExecuteJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -333,41 +630,51 @@ func (x *ProcessJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProcessJobRequest.ProtoReflect.Descriptor instead.
-func (*ProcessJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_jobqueue_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ExecuteJobRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ProcessJobRequest) GetJobId() string {
+func (x *ExecuteJobRequest) GetJob() *SubmitJobRequest {
 	if x != nil {
-		return x.JobId
+		return x.Job
 	}
-	return ""
+	return nil
 }
 
-type ProcessJobResponse struct {
+func (x *ExecuteJobRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+type ExecuteJobResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Result        string                 `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Done          bool                   `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProcessJobResponse) Reset() {
-	*x = ProcessJobResponse{}
-	mi := &file_proto_jobqueue_proto_msgTypes[5]
+func (x *ExecuteJobResponse) Reset() {
+	*x = ExecuteJobResponse{}
+	mi := &file_proto_jobqueue_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProcessJobResponse) String() string {
+func (x *ExecuteJobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProcessJobResponse) ProtoMessage() {}
+func (*ExecuteJobResponse) ProtoMessage() {}
 
-func (x *ProcessJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_jobqueue_proto_msgTypes[5]
+func (x *ExecuteJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -378,16 +685,1113 @@ func (x *ProcessJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProcessJobResponse.ProtoReflect.Descriptor instead.
-func (*ProcessJobResponse) Descriptor() ([]byte, []int) {
-	return file_proto_jobqueue_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ExecuteJobResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ProcessJobResponse) GetSuccess() bool {
+func (x *ExecuteJobResponse) GetJobId() string {
 	if x != nil {
-		return x.Success
+		return x.JobId
 	}
-	return false
+	return ""
+}
+
+func (x *ExecuteJobResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExecuteJobResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *ExecuteJobResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExecuteJobResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+type WorkflowStepDefinition struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Name                string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Payload             string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	MaxAttempts         int32                  `protobuf:"varint,4,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	DependsOn           []string               `protobuf:"bytes,5,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	InputFrom           string                 `protobuf:"bytes,6,opt,name=input_from,json=inputFrom,proto3" json:"input_from,omitempty"`
+	CompensationType    string                 `protobuf:"bytes,7,opt,name=compensation_type,json=compensationType,proto3" json:"compensation_type,omitempty"`
+	CompensationPayload string                 `protobuf:"bytes,8,opt,name=compensation_payload,json=compensationPayload,proto3" json:"compensation_payload,omitempty"`
+	Queue               string                 `protobuf:"bytes,9,opt,name=queue,proto3" json:"queue,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *WorkflowStepDefinition) Reset() {
+	*x = WorkflowStepDefinition{}
+	mi := &file_proto_jobqueue_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowStepDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowStepDefinition) ProtoMessage() {}
+
+func (x *WorkflowStepDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowStepDefinition.ProtoReflect.Descriptor instead.
+func (*WorkflowStepDefinition) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WorkflowStepDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowStepDefinition) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WorkflowStepDefinition) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *WorkflowStepDefinition) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *WorkflowStepDefinition) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *WorkflowStepDefinition) GetInputFrom() string {
+	if x != nil {
+		return x.InputFrom
+	}
+	return ""
+}
+
+func (x *WorkflowStepDefinition) GetCompensationType() string {
+	if x != nil {
+		return x.CompensationType
+	}
+	return ""
+}
+
+func (x *WorkflowStepDefinition) GetCompensationPayload() string {
+	if x != nil {
+		return x.CompensationPayload
+	}
+	return ""
+}
+
+func (x *WorkflowStepDefinition) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+type SubmitWorkflowRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Name          string                    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Steps         []*WorkflowStepDefinition `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	Output        string                    `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitWorkflowRequest) Reset() {
+	*x = SubmitWorkflowRequest{}
+	mi := &file_proto_jobqueue_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitWorkflowRequest) ProtoMessage() {}
+
+func (x *SubmitWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*SubmitWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubmitWorkflowRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubmitWorkflowRequest) GetSteps() []*WorkflowStepDefinition {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *SubmitWorkflowRequest) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+type GetWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkflowRequest) Reset() {
+	*x = GetWorkflowRequest{}
+	mi := &file_proto_jobqueue_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkflowRequest) ProtoMessage() {}
+
+func (x *GetWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetWorkflowRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+type WorkflowStepStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	DependsOn     []string               `protobuf:"bytes,4,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	InputFrom     string                 `protobuf:"bytes,5,opt,name=input_from,json=inputFrom,proto3" json:"input_from,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Result        string                 `protobuf:"bytes,7,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowStepStatus) Reset() {
+	*x = WorkflowStepStatus{}
+	mi := &file_proto_jobqueue_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowStepStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowStepStatus) ProtoMessage() {}
+
+func (x *WorkflowStepStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowStepStatus.ProtoReflect.Descriptor instead.
+func (*WorkflowStepStatus) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WorkflowStepStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowStepStatus) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *WorkflowStepStatus) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WorkflowStepStatus) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *WorkflowStepStatus) GetInputFrom() string {
+	if x != nil {
+		return x.InputFrom
+	}
+	return ""
+}
+
+func (x *WorkflowStepStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WorkflowStepStatus) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *WorkflowStepStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type WorkflowStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	OutputStep    string                 `protobuf:"bytes,4,opt,name=output_step,json=outputStep,proto3" json:"output_step,omitempty"`
+	Output        string                 `protobuf:"bytes,5,opt,name=output,proto3" json:"output,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Steps         []*WorkflowStepStatus  `protobuf:"bytes,7,rep,name=steps,proto3" json:"steps,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowStatusResponse) Reset() {
+	*x = WorkflowStatusResponse{}
+	mi := &file_proto_jobqueue_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowStatusResponse) ProtoMessage() {}
+
+func (x *WorkflowStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowStatusResponse.ProtoReflect.Descriptor instead.
+func (*WorkflowStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WorkflowStatusResponse) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *WorkflowStatusResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WorkflowStatusResponse) GetOutputStep() string {
+	if x != nil {
+		return x.OutputStep
+	}
+	return ""
+}
+
+func (x *WorkflowStatusResponse) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *WorkflowStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WorkflowStatusResponse) GetSteps() []*WorkflowStepStatus {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *WorkflowStatusResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *WorkflowStatusResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type QueueControlRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueControlRequest) Reset() {
+	*x = QueueControlRequest{}
+	mi := &file_proto_jobqueue_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueControlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueControlRequest) ProtoMessage() {}
+
+func (x *QueueControlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueControlRequest.ProtoReflect.Descriptor instead.
+func (*QueueControlRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QueueControlRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *QueueControlRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueueControlRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type QueueState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueState) Reset() {
+	*x = QueueState{}
+	mi := &file_proto_jobqueue_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueState) ProtoMessage() {}
+
+func (x *QueueState) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueState.ProtoReflect.Descriptor instead.
+func (*QueueState) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *QueueState) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *QueueState) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueueState) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *QueueState) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *QueueState) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ListQueueStatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQueueStatesRequest) Reset() {
+	*x = ListQueueStatesRequest{}
+	mi := &file_proto_jobqueue_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQueueStatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueueStatesRequest) ProtoMessage() {}
+
+func (x *ListQueueStatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueueStatesRequest.ProtoReflect.Descriptor instead.
+func (*ListQueueStatesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{15}
+}
+
+type ListQueueStatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	States        []*QueueState          `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQueueStatesResponse) Reset() {
+	*x = ListQueueStatesResponse{}
+	mi := &file_proto_jobqueue_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQueueStatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQueueStatesResponse) ProtoMessage() {}
+
+func (x *ListQueueStatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQueueStatesResponse.ProtoReflect.Descriptor instead.
+func (*ListQueueStatesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListQueueStatesResponse) GetStates() []*QueueState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type SetTypeLimitRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	MaxConcurrency int32                  `protobuf:"varint,2,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SetTypeLimitRequest) Reset() {
+	*x = SetTypeLimitRequest{}
+	mi := &file_proto_jobqueue_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTypeLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTypeLimitRequest) ProtoMessage() {}
+
+func (x *SetTypeLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTypeLimitRequest.ProtoReflect.Descriptor instead.
+func (*SetTypeLimitRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetTypeLimitRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SetTypeLimitRequest) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+type TypeLimit struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	MaxConcurrency int32                  `protobuf:"varint,2,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	Running        int32                  `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TypeLimit) Reset() {
+	*x = TypeLimit{}
+	mi := &file_proto_jobqueue_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypeLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypeLimit) ProtoMessage() {}
+
+func (x *TypeLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypeLimit.ProtoReflect.Descriptor instead.
+func (*TypeLimit) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TypeLimit) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TypeLimit) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *TypeLimit) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *TypeLimit) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ListTypeLimitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTypeLimitsRequest) Reset() {
+	*x = ListTypeLimitsRequest{}
+	mi := &file_proto_jobqueue_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTypeLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTypeLimitsRequest) ProtoMessage() {}
+
+func (x *ListTypeLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTypeLimitsRequest.ProtoReflect.Descriptor instead.
+func (*ListTypeLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{19}
+}
+
+type ListTypeLimitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        []*TypeLimit           `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTypeLimitsResponse) Reset() {
+	*x = ListTypeLimitsResponse{}
+	mi := &file_proto_jobqueue_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTypeLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTypeLimitsResponse) ProtoMessage() {}
+
+func (x *ListTypeLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTypeLimitsResponse.ProtoReflect.Descriptor instead.
+func (*ListTypeLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListTypeLimitsResponse) GetLimits() []*TypeLimit {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type StreamJobLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	AfterId       int64                  `protobuf:"varint,2,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	Follow        bool                   `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamJobLogsRequest) Reset() {
+	*x = StreamJobLogsRequest{}
+	mi := &file_proto_jobqueue_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamJobLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamJobLogsRequest) ProtoMessage() {}
+
+func (x *StreamJobLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamJobLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamJobLogsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StreamJobLogsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *StreamJobLogsRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+func (x *StreamJobLogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+type JobLogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Attempt       int32                  `protobuf:"varint,3,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Level         string                 `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobLogEntry) Reset() {
+	*x = JobLogEntry{}
+	mi := &file_proto_jobqueue_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobLogEntry) ProtoMessage() {}
+
+func (x *JobLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobLogEntry.ProtoReflect.Descriptor instead.
+func (*JobLogEntry) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *JobLogEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *JobLogEntry) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobLogEntry) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *JobLogEntry) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *JobLogEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *JobLogEntry) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ProcessJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessJobRequest) Reset() {
+	*x = ProcessJobRequest{}
+	mi := &file_proto_jobqueue_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessJobRequest) ProtoMessage() {}
+
+func (x *ProcessJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessJobRequest.ProtoReflect.Descriptor instead.
+func (*ProcessJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProcessJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type ProcessJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessJobResponse) Reset() {
+	*x = ProcessJobResponse{}
+	mi := &file_proto_jobqueue_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessJobResponse) ProtoMessage() {}
+
+func (x *ProcessJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_jobqueue_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessJobResponse.ProtoReflect.Descriptor instead.
+func (*ProcessJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_jobqueue_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ProcessJobResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
 }
 
 func (x *ProcessJobResponse) GetMessage() string {
@@ -401,18 +1805,43 @@ var File_proto_jobqueue_proto protoreflect.FileDescriptor
 
 const file_proto_jobqueue_proto_rawDesc = "" +
 	"\n" +
-	"\x14proto/jobqueue.proto\x12\bjobqueue\"c\n" +
+	"\x14proto/jobqueue.proto\x12\bjobqueue\"\x9f\x04\n" +
 	"\x10SubmitJobRequest\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
 	"\apayload\x18\x02 \x01(\tR\apayload\x12!\n" +
-	"\fmax_attempts\x18\x03 \x01(\x05R\vmaxAttempts\"a\n" +
+	"\fmax_attempts\x18\x03 \x01(\x05R\vmaxAttempts\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x04 \x03(\tR\tdependsOn\x12+\n" +
+	"\x11dependency_policy\x18\x05 \x01(\tR\x10dependencyPolicy\x12+\n" +
+	"\x11compensation_type\x18\x06 \x01(\tR\x10compensationType\x121\n" +
+	"\x14compensation_payload\x18\a \x01(\tR\x13compensationPayload\x12\x14\n" +
+	"\x05queue\x18\b \x01(\tR\x05queue\x12\x1d\n" +
+	"\n" +
+	"unique_key\x18\t \x01(\tR\tuniqueKey\x12!\n" +
+	"\funique_scope\x18\n" +
+	" \x01(\tR\vuniqueScope\x12#\n" +
+	"\runique_window\x18\v \x01(\tR\funiqueWindow\x12!\n" +
+	"\fon_duplicate\x18\f \x01(\tR\vonDuplicate\x12!\n" +
+	"\fordering_key\x18\r \x01(\tR\vorderingKey\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x0e \x01(\tR\texpiresAt\x12\x1f\n" +
+	"\bpriority\x18\x0f \x01(\x05H\x00R\bpriority\x88\x01\x01B\v\n" +
+	"\t_priority\"a\n" +
 	"\x11SubmitJobResponse\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x03 \x01(\tR\tcreatedAt\"&\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\"U\n" +
+	"\x10SubmitJobsResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\x82\x01\n" +
+	"\x12SubmitJobsResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.jobqueue.SubmitJobsResultR\aresults\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x05R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x03 \x01(\x05R\brejected\"&\n" +
 	"\rGetJobRequest\x12\x15\n" +
-	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\x9b\x02\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xf6\x04\n" +
 	"\x11JobStatusResponse\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
@@ -426,17 +1855,139 @@ const file_proto_jobqueue_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\tR\tupdatedAt\"*\n" +
+	" \x01(\tR\tupdatedAt\x12/\n" +
+	"\x13compensation_status\x18\v \x01(\tR\x12compensationStatus\x12.\n" +
+	"\x13compensation_job_id\x18\f \x01(\tR\x11compensationJobId\x12\x14\n" +
+	"\x05queue\x18\r \x01(\tR\x05queue\x12\x1d\n" +
+	"\n" +
+	"unique_key\x18\x0e \x01(\tR\tuniqueKey\x12!\n" +
+	"\fordering_key\x18\x0f \x01(\tR\vorderingKey\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x10 \x01(\tR\texpiresAt\x12\x1a\n" +
+	"\bprogress\x18\x11 \x01(\x05R\bprogress\x12)\n" +
+	"\x10progress_message\x18\x12 \x01(\tR\x0fprogressMessage\x12\x1e\n" +
+	"\n" +
+	"checkpoint\x18\x13 \x01(\tR\n" +
+	"checkpoint\x12\x1a\n" +
+	"\bpriority\x18\x14 \x01(\x05R\bpriority\"`\n" +
+	"\x11ExecuteJobRequest\x12,\n" +
+	"\x03job\x18\x01 \x01(\v2\x1a.jobqueue.SubmitJobRequestR\x03job\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x02 \x01(\x03R\ttimeoutMs\"\x85\x01\n" +
+	"\x12ExecuteJobResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06result\x18\x03 \x01(\tR\x06result\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\bR\x04done\"\xb1\x02\n" +
+	"\x16WorkflowStepDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x12!\n" +
+	"\fmax_attempts\x18\x04 \x01(\x05R\vmaxAttempts\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x05 \x03(\tR\tdependsOn\x12\x1d\n" +
+	"\n" +
+	"input_from\x18\x06 \x01(\tR\tinputFrom\x12+\n" +
+	"\x11compensation_type\x18\a \x01(\tR\x10compensationType\x121\n" +
+	"\x14compensation_payload\x18\b \x01(\tR\x13compensationPayload\x12\x14\n" +
+	"\x05queue\x18\t \x01(\tR\x05queue\"{\n" +
+	"\x15SubmitWorkflowRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x126\n" +
+	"\x05steps\x18\x02 \x03(\v2 .jobqueue.WorkflowStepDefinitionR\x05steps\x12\x16\n" +
+	"\x06output\x18\x03 \x01(\tR\x06output\"5\n" +
+	"\x12GetWorkflowRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"\xd7\x01\n" +
+	"\x12WorkflowStepStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x04 \x03(\tR\tdependsOn\x12\x1d\n" +
+	"\n" +
+	"input_from\x18\x05 \x01(\tR\tinputFrom\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x16\n" +
+	"\x06result\x18\a \x01(\tR\x06result\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"\xa6\x02\n" +
+	"\x16WorkflowStatusResponse\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\voutput_step\x18\x04 \x01(\tR\n" +
+	"outputStep\x12\x16\n" +
+	"\x06output\x18\x05 \x01(\tR\x06output\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x122\n" +
+	"\x05steps\x18\a \x03(\v2\x1c.jobqueue.WorkflowStepStatusR\x05steps\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\"U\n" +
+	"\x13QueueControlRequest\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x81\x01\n" +
+	"\n" +
+	"QueueState\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"\x18\n" +
+	"\x16ListQueueStatesRequest\"G\n" +
+	"\x17ListQueueStatesResponse\x12,\n" +
+	"\x06states\x18\x01 \x03(\v2\x14.jobqueue.QueueStateR\x06states\"R\n" +
+	"\x13SetTypeLimitRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12'\n" +
+	"\x0fmax_concurrency\x18\x02 \x01(\x05R\x0emaxConcurrency\"\x81\x01\n" +
+	"\tTypeLimit\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12'\n" +
+	"\x0fmax_concurrency\x18\x02 \x01(\x05R\x0emaxConcurrency\x12\x18\n" +
+	"\arunning\x18\x03 \x01(\x05R\arunning\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\tR\tupdatedAt\"\x17\n" +
+	"\x15ListTypeLimitsRequest\"E\n" +
+	"\x16ListTypeLimitsResponse\x12+\n" +
+	"\x06limits\x18\x01 \x03(\v2\x13.jobqueue.TypeLimitR\x06limits\"`\n" +
+	"\x14StreamJobLogsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x19\n" +
+	"\bafter_id\x18\x02 \x01(\x03R\aafterId\x12\x16\n" +
+	"\x06follow\x18\x03 \x01(\bR\x06follow\"\x9d\x01\n" +
+	"\vJobLogEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x18\n" +
+	"\aattempt\x18\x03 \x01(\x05R\aattempt\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\tR\x05level\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"*\n" +
 	"\x11ProcessJobRequest\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"H\n" +
 	"\x12ProcessJobResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xba\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\xf6\b\n" +
 	"\rWorkerService\x12D\n" +
 	"\tSubmitJob\x12\x1a.jobqueue.SubmitJobRequest\x1a\x1b.jobqueue.SubmitJobResponse\x12D\n" +
 	"\fGetJobStatus\x12\x17.jobqueue.GetJobRequest\x1a\x1b.jobqueue.JobStatusResponse\x12O\n" +
 	"\x12NotifyJobCompleted\x12\x1b.jobqueue.ProcessJobRequest\x1a\x1c.jobqueue.ProcessJobResponse\x12L\n" +
-	"\x0fNotifyJobFailed\x12\x1b.jobqueue.ProcessJobRequest\x1a\x1c.jobqueue.ProcessJobResponseB#Z!github.com/mtr002/Job-Queue/protob\x06proto3"
+	"\x0fNotifyJobFailed\x12\x1b.jobqueue.ProcessJobRequest\x1a\x1c.jobqueue.ProcessJobResponse\x12G\n" +
+	"\n" +
+	"ExecuteJob\x12\x1b.jobqueue.ExecuteJobRequest\x1a\x1c.jobqueue.ExecuteJobResponse\x12H\n" +
+	"\n" +
+	"SubmitJobs\x12\x1a.jobqueue.SubmitJobRequest\x1a\x1c.jobqueue.SubmitJobsResponse(\x01\x12S\n" +
+	"\x0eSubmitWorkflow\x12\x1f.jobqueue.SubmitWorkflowRequest\x1a .jobqueue.WorkflowStatusResponse\x12M\n" +
+	"\vGetWorkflow\x12\x1c.jobqueue.GetWorkflowRequest\x1a .jobqueue.WorkflowStatusResponse\x12A\n" +
+	"\n" +
+	"PauseQueue\x12\x1d.jobqueue.QueueControlRequest\x1a\x14.jobqueue.QueueState\x12B\n" +
+	"\vResumeQueue\x12\x1d.jobqueue.QueueControlRequest\x1a\x14.jobqueue.QueueState\x12A\n" +
+	"\n" +
+	"DrainQueue\x12\x1d.jobqueue.QueueControlRequest\x1a\x14.jobqueue.QueueState\x12V\n" +
+	"\x0fListQueueStates\x12 .jobqueue.ListQueueStatesRequest\x1a!.jobqueue.ListQueueStatesResponse\x12B\n" +
+	"\fSetTypeLimit\x12\x1d.jobqueue.SetTypeLimitRequest\x1a\x13.jobqueue.TypeLimit\x12S\n" +
+	"\x0eListTypeLimits\x12\x1f.jobqueue.ListTypeLimitsRequest\x1a .jobqueue.ListTypeLimitsResponse\x12H\n" +
+	"\rStreamJobLogs\x12\x1e.jobqueue.StreamJobLogsRequest\x1a\x15.jobqueue.JobLogEntry0\x01B#Z!github.com/mtr002/Job-Queue/protob\x06proto3"
 
 var (
 	file_proto_jobqueue_proto_rawDescOnce sync.Once
@@ -450,29 +2001,76 @@ func file_proto_jobqueue_proto_rawDescGZIP() []byte {
 	return file_proto_jobqueue_proto_rawDescData
 }
 
-var file_proto_jobqueue_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_jobqueue_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_proto_jobqueue_proto_goTypes = []any{
-	(*SubmitJobRequest)(nil),   // 0: jobqueue.SubmitJobRequest
-	(*SubmitJobResponse)(nil),  // 1: jobqueue.SubmitJobResponse
-	(*GetJobRequest)(nil),      // 2: jobqueue.GetJobRequest
-	(*JobStatusResponse)(nil),  // 3: jobqueue.JobStatusResponse
-	(*ProcessJobRequest)(nil),  // 4: jobqueue.ProcessJobRequest
-	(*ProcessJobResponse)(nil), // 5: jobqueue.ProcessJobResponse
+	(*SubmitJobRequest)(nil),        // 0: jobqueue.SubmitJobRequest
+	(*SubmitJobResponse)(nil),       // 1: jobqueue.SubmitJobResponse
+	(*SubmitJobsResult)(nil),        // 2: jobqueue.SubmitJobsResult
+	(*SubmitJobsResponse)(nil),      // 3: jobqueue.SubmitJobsResponse
+	(*GetJobRequest)(nil),           // 4: jobqueue.GetJobRequest
+	(*JobStatusResponse)(nil),       // 5: jobqueue.JobStatusResponse
+	(*ExecuteJobRequest)(nil),       // 6: jobqueue.ExecuteJobRequest
+	(*ExecuteJobResponse)(nil),      // 7: jobqueue.ExecuteJobResponse
+	(*WorkflowStepDefinition)(nil),  // 8: jobqueue.WorkflowStepDefinition
+	(*SubmitWorkflowRequest)(nil),   // 9: jobqueue.SubmitWorkflowRequest
+	(*GetWorkflowRequest)(nil),      // 10: jobqueue.GetWorkflowRequest
+	(*WorkflowStepStatus)(nil),      // 11: jobqueue.WorkflowStepStatus
+	(*WorkflowStatusResponse)(nil),  // 12: jobqueue.WorkflowStatusResponse
+	(*QueueControlRequest)(nil),     // 13: jobqueue.QueueControlRequest
+	(*QueueState)(nil),              // 14: jobqueue.QueueState
+	(*ListQueueStatesRequest)(nil),  // 15: jobqueue.ListQueueStatesRequest
+	(*ListQueueStatesResponse)(nil), // 16: jobqueue.ListQueueStatesResponse
+	(*SetTypeLimitRequest)(nil),     // 17: jobqueue.SetTypeLimitRequest
+	(*TypeLimit)(nil),               // 18: jobqueue.TypeLimit
+	(*ListTypeLimitsRequest)(nil),   // 19: jobqueue.ListTypeLimitsRequest
+	(*ListTypeLimitsResponse)(nil),  // 20: jobqueue.ListTypeLimitsResponse
+	(*StreamJobLogsRequest)(nil),    // 21: jobqueue.StreamJobLogsRequest
+	(*JobLogEntry)(nil),             // 22: jobqueue.JobLogEntry
+	(*ProcessJobRequest)(nil),       // 23: jobqueue.ProcessJobRequest
+	(*ProcessJobResponse)(nil),      // 24: jobqueue.ProcessJobResponse
 }
 var file_proto_jobqueue_proto_depIdxs = []int32{
-	0, // 0: jobqueue.WorkerService.SubmitJob:input_type -> jobqueue.SubmitJobRequest
-	2, // 1: jobqueue.WorkerService.GetJobStatus:input_type -> jobqueue.GetJobRequest
-	4, // 2: jobqueue.WorkerService.NotifyJobCompleted:input_type -> jobqueue.ProcessJobRequest
-	4, // 3: jobqueue.WorkerService.NotifyJobFailed:input_type -> jobqueue.ProcessJobRequest
-	1, // 4: jobqueue.WorkerService.SubmitJob:output_type -> jobqueue.SubmitJobResponse
-	3, // 5: jobqueue.WorkerService.GetJobStatus:output_type -> jobqueue.JobStatusResponse
-	5, // 6: jobqueue.WorkerService.NotifyJobCompleted:output_type -> jobqueue.ProcessJobResponse
-	5, // 7: jobqueue.WorkerService.NotifyJobFailed:output_type -> jobqueue.ProcessJobResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2,  // 0: jobqueue.SubmitJobsResponse.results:type_name -> jobqueue.SubmitJobsResult
+	0,  // 1: jobqueue.ExecuteJobRequest.job:type_name -> jobqueue.SubmitJobRequest
+	8,  // 2: jobqueue.SubmitWorkflowRequest.steps:type_name -> jobqueue.WorkflowStepDefinition
+	11, // 3: jobqueue.WorkflowStatusResponse.steps:type_name -> jobqueue.WorkflowStepStatus
+	14, // 4: jobqueue.ListQueueStatesResponse.states:type_name -> jobqueue.QueueState
+	18, // 5: jobqueue.ListTypeLimitsResponse.limits:type_name -> jobqueue.TypeLimit
+	0,  // 6: jobqueue.WorkerService.SubmitJob:input_type -> jobqueue.SubmitJobRequest
+	4,  // 7: jobqueue.WorkerService.GetJobStatus:input_type -> jobqueue.GetJobRequest
+	23, // 8: jobqueue.WorkerService.NotifyJobCompleted:input_type -> jobqueue.ProcessJobRequest
+	23, // 9: jobqueue.WorkerService.NotifyJobFailed:input_type -> jobqueue.ProcessJobRequest
+	6,  // 10: jobqueue.WorkerService.ExecuteJob:input_type -> jobqueue.ExecuteJobRequest
+	0,  // 11: jobqueue.WorkerService.SubmitJobs:input_type -> jobqueue.SubmitJobRequest
+	9,  // 12: jobqueue.WorkerService.SubmitWorkflow:input_type -> jobqueue.SubmitWorkflowRequest
+	10, // 13: jobqueue.WorkerService.GetWorkflow:input_type -> jobqueue.GetWorkflowRequest
+	13, // 14: jobqueue.WorkerService.PauseQueue:input_type -> jobqueue.QueueControlRequest
+	13, // 15: jobqueue.WorkerService.ResumeQueue:input_type -> jobqueue.QueueControlRequest
+	13, // 16: jobqueue.WorkerService.DrainQueue:input_type -> jobqueue.QueueControlRequest
+	15, // 17: jobqueue.WorkerService.ListQueueStates:input_type -> jobqueue.ListQueueStatesRequest
+	17, // 18: jobqueue.WorkerService.SetTypeLimit:input_type -> jobqueue.SetTypeLimitRequest
+	19, // 19: jobqueue.WorkerService.ListTypeLimits:input_type -> jobqueue.ListTypeLimitsRequest
+	21, // 20: jobqueue.WorkerService.StreamJobLogs:input_type -> jobqueue.StreamJobLogsRequest
+	1,  // 21: jobqueue.WorkerService.SubmitJob:output_type -> jobqueue.SubmitJobResponse
+	5,  // 22: jobqueue.WorkerService.GetJobStatus:output_type -> jobqueue.JobStatusResponse
+	24, // 23: jobqueue.WorkerService.NotifyJobCompleted:output_type -> jobqueue.ProcessJobResponse
+	24, // 24: jobqueue.WorkerService.NotifyJobFailed:output_type -> jobqueue.ProcessJobResponse
+	7,  // 25: jobqueue.WorkerService.ExecuteJob:output_type -> jobqueue.ExecuteJobResponse
+	3,  // 26: jobqueue.WorkerService.SubmitJobs:output_type -> jobqueue.SubmitJobsResponse
+	12, // 27: jobqueue.WorkerService.SubmitWorkflow:output_type -> jobqueue.WorkflowStatusResponse
+	12, // 28: jobqueue.WorkerService.GetWorkflow:output_type -> jobqueue.WorkflowStatusResponse
+	14, // 29: jobqueue.WorkerService.PauseQueue:output_type -> jobqueue.QueueState
+	14, // 30: jobqueue.WorkerService.ResumeQueue:output_type -> jobqueue.QueueState
+	14, // 31: jobqueue.WorkerService.DrainQueue:output_type -> jobqueue.QueueState
+	16, // 32: jobqueue.WorkerService.ListQueueStates:output_type -> jobqueue.ListQueueStatesResponse
+	18, // 33: jobqueue.WorkerService.SetTypeLimit:output_type -> jobqueue.TypeLimit
+	20, // 34: jobqueue.WorkerService.ListTypeLimits:output_type -> jobqueue.ListTypeLimitsResponse
+	22, // 35: jobqueue.WorkerService.StreamJobLogs:output_type -> jobqueue.JobLogEntry
+	21, // [21:36] is the sub-list for method output_type
+	6,  // [6:21] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_proto_jobqueue_proto_init() }
@@ -480,13 +2078,14 @@ func file_proto_jobqueue_proto_init() {
 	if File_proto_jobqueue_proto != nil {
 		return
 	}
+	file_proto_jobqueue_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_jobqueue_proto_rawDesc), len(file_proto_jobqueue_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
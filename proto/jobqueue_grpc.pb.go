@@ -23,6 +23,17 @@ const (
 	WorkerService_GetJobStatus_FullMethodName       = "/jobqueue.WorkerService/GetJobStatus"
 	WorkerService_NotifyJobCompleted_FullMethodName = "/jobqueue.WorkerService/NotifyJobCompleted"
 	WorkerService_NotifyJobFailed_FullMethodName    = "/jobqueue.WorkerService/NotifyJobFailed"
+	WorkerService_ExecuteJob_FullMethodName         = "/jobqueue.WorkerService/ExecuteJob"
+	WorkerService_SubmitJobs_FullMethodName         = "/jobqueue.WorkerService/SubmitJobs"
+	WorkerService_SubmitWorkflow_FullMethodName     = "/jobqueue.WorkerService/SubmitWorkflow"
+	WorkerService_GetWorkflow_FullMethodName        = "/jobqueue.WorkerService/GetWorkflow"
+	WorkerService_PauseQueue_FullMethodName         = "/jobqueue.WorkerService/PauseQueue"
+	WorkerService_ResumeQueue_FullMethodName        = "/jobqueue.WorkerService/ResumeQueue"
+	WorkerService_DrainQueue_FullMethodName         = "/jobqueue.WorkerService/DrainQueue"
+	WorkerService_ListQueueStates_FullMethodName    = "/jobqueue.WorkerService/ListQueueStates"
+	WorkerService_SetTypeLimit_FullMethodName       = "/jobqueue.WorkerService/SetTypeLimit"
+	WorkerService_ListTypeLimits_FullMethodName     = "/jobqueue.WorkerService/ListTypeLimits"
+	WorkerService_StreamJobLogs_FullMethodName      = "/jobqueue.WorkerService/StreamJobLogs"
 )
 
 // WorkerServiceClient is the client API for WorkerService service.
@@ -33,6 +44,17 @@ type WorkerServiceClient interface {
 	GetJobStatus(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*JobStatusResponse, error)
 	NotifyJobCompleted(ctx context.Context, in *ProcessJobRequest, opts ...grpc.CallOption) (*ProcessJobResponse, error)
 	NotifyJobFailed(ctx context.Context, in *ProcessJobRequest, opts ...grpc.CallOption) (*ProcessJobResponse, error)
+	ExecuteJob(ctx context.Context, in *ExecuteJobRequest, opts ...grpc.CallOption) (*ExecuteJobResponse, error)
+	SubmitJobs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SubmitJobRequest, SubmitJobsResponse], error)
+	SubmitWorkflow(ctx context.Context, in *SubmitWorkflowRequest, opts ...grpc.CallOption) (*WorkflowStatusResponse, error)
+	GetWorkflow(ctx context.Context, in *GetWorkflowRequest, opts ...grpc.CallOption) (*WorkflowStatusResponse, error)
+	PauseQueue(ctx context.Context, in *QueueControlRequest, opts ...grpc.CallOption) (*QueueState, error)
+	ResumeQueue(ctx context.Context, in *QueueControlRequest, opts ...grpc.CallOption) (*QueueState, error)
+	DrainQueue(ctx context.Context, in *QueueControlRequest, opts ...grpc.CallOption) (*QueueState, error)
+	ListQueueStates(ctx context.Context, in *ListQueueStatesRequest, opts ...grpc.CallOption) (*ListQueueStatesResponse, error)
+	SetTypeLimit(ctx context.Context, in *SetTypeLimitRequest, opts ...grpc.CallOption) (*TypeLimit, error)
+	ListTypeLimits(ctx context.Context, in *ListTypeLimitsRequest, opts ...grpc.CallOption) (*ListTypeLimitsResponse, error)
+	StreamJobLogs(ctx context.Context, in *StreamJobLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobLogEntry], error)
 }
 
 type workerServiceClient struct {
@@ -83,6 +105,128 @@ func (c *workerServiceClient) NotifyJobFailed(ctx context.Context, in *ProcessJo
 	return out, nil
 }
 
+func (c *workerServiceClient) ExecuteJob(ctx context.Context, in *ExecuteJobRequest, opts ...grpc.CallOption) (*ExecuteJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteJobResponse)
+	err := c.cc.Invoke(ctx, WorkerService_ExecuteJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) SubmitJobs(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[SubmitJobRequest, SubmitJobsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WorkerService_ServiceDesc.Streams[0], WorkerService_SubmitJobs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubmitJobRequest, SubmitJobsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WorkerService_SubmitJobsClient = grpc.ClientStreamingClient[SubmitJobRequest, SubmitJobsResponse]
+
+func (c *workerServiceClient) SubmitWorkflow(ctx context.Context, in *SubmitWorkflowRequest, opts ...grpc.CallOption) (*WorkflowStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkflowStatusResponse)
+	err := c.cc.Invoke(ctx, WorkerService_SubmitWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) GetWorkflow(ctx context.Context, in *GetWorkflowRequest, opts ...grpc.CallOption) (*WorkflowStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkflowStatusResponse)
+	err := c.cc.Invoke(ctx, WorkerService_GetWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) PauseQueue(ctx context.Context, in *QueueControlRequest, opts ...grpc.CallOption) (*QueueState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueueState)
+	err := c.cc.Invoke(ctx, WorkerService_PauseQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) ResumeQueue(ctx context.Context, in *QueueControlRequest, opts ...grpc.CallOption) (*QueueState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueueState)
+	err := c.cc.Invoke(ctx, WorkerService_ResumeQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) DrainQueue(ctx context.Context, in *QueueControlRequest, opts ...grpc.CallOption) (*QueueState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueueState)
+	err := c.cc.Invoke(ctx, WorkerService_DrainQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) ListQueueStates(ctx context.Context, in *ListQueueStatesRequest, opts ...grpc.CallOption) (*ListQueueStatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQueueStatesResponse)
+	err := c.cc.Invoke(ctx, WorkerService_ListQueueStates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) SetTypeLimit(ctx context.Context, in *SetTypeLimitRequest, opts ...grpc.CallOption) (*TypeLimit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TypeLimit)
+	err := c.cc.Invoke(ctx, WorkerService_SetTypeLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) ListTypeLimits(ctx context.Context, in *ListTypeLimitsRequest, opts ...grpc.CallOption) (*ListTypeLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTypeLimitsResponse)
+	err := c.cc.Invoke(ctx, WorkerService_ListTypeLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerServiceClient) StreamJobLogs(ctx context.Context, in *StreamJobLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JobLogEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WorkerService_ServiceDesc.Streams[1], WorkerService_StreamJobLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamJobLogsRequest, JobLogEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WorkerService_StreamJobLogsClient = grpc.ServerStreamingClient[JobLogEntry]
+
 // WorkerServiceServer is the server API for WorkerService service.
 // All implementations must embed UnimplementedWorkerServiceServer
 // for forward compatibility.
@@ -91,6 +235,17 @@ type WorkerServiceServer interface {
 	GetJobStatus(context.Context, *GetJobRequest) (*JobStatusResponse, error)
 	NotifyJobCompleted(context.Context, *ProcessJobRequest) (*ProcessJobResponse, error)
 	NotifyJobFailed(context.Context, *ProcessJobRequest) (*ProcessJobResponse, error)
+	ExecuteJob(context.Context, *ExecuteJobRequest) (*ExecuteJobResponse, error)
+	SubmitJobs(grpc.ClientStreamingServer[SubmitJobRequest, SubmitJobsResponse]) error
+	SubmitWorkflow(context.Context, *SubmitWorkflowRequest) (*WorkflowStatusResponse, error)
+	GetWorkflow(context.Context, *GetWorkflowRequest) (*WorkflowStatusResponse, error)
+	PauseQueue(context.Context, *QueueControlRequest) (*QueueState, error)
+	ResumeQueue(context.Context, *QueueControlRequest) (*QueueState, error)
+	DrainQueue(context.Context, *QueueControlRequest) (*QueueState, error)
+	ListQueueStates(context.Context, *ListQueueStatesRequest) (*ListQueueStatesResponse, error)
+	SetTypeLimit(context.Context, *SetTypeLimitRequest) (*TypeLimit, error)
+	ListTypeLimits(context.Context, *ListTypeLimitsRequest) (*ListTypeLimitsResponse, error)
+	StreamJobLogs(*StreamJobLogsRequest, grpc.ServerStreamingServer[JobLogEntry]) error
 	mustEmbedUnimplementedWorkerServiceServer()
 }
 
@@ -113,6 +268,39 @@ func (UnimplementedWorkerServiceServer) NotifyJobCompleted(context.Context, *Pro
 func (UnimplementedWorkerServiceServer) NotifyJobFailed(context.Context, *ProcessJobRequest) (*ProcessJobResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method NotifyJobFailed not implemented")
 }
+func (UnimplementedWorkerServiceServer) ExecuteJob(context.Context, *ExecuteJobRequest) (*ExecuteJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExecuteJob not implemented")
+}
+func (UnimplementedWorkerServiceServer) SubmitJobs(grpc.ClientStreamingServer[SubmitJobRequest, SubmitJobsResponse]) error {
+	return status.Error(codes.Unimplemented, "method SubmitJobs not implemented")
+}
+func (UnimplementedWorkerServiceServer) SubmitWorkflow(context.Context, *SubmitWorkflowRequest) (*WorkflowStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitWorkflow not implemented")
+}
+func (UnimplementedWorkerServiceServer) GetWorkflow(context.Context, *GetWorkflowRequest) (*WorkflowStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWorkflow not implemented")
+}
+func (UnimplementedWorkerServiceServer) PauseQueue(context.Context, *QueueControlRequest) (*QueueState, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseQueue not implemented")
+}
+func (UnimplementedWorkerServiceServer) ResumeQueue(context.Context, *QueueControlRequest) (*QueueState, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResumeQueue not implemented")
+}
+func (UnimplementedWorkerServiceServer) DrainQueue(context.Context, *QueueControlRequest) (*QueueState, error) {
+	return nil, status.Error(codes.Unimplemented, "method DrainQueue not implemented")
+}
+func (UnimplementedWorkerServiceServer) ListQueueStates(context.Context, *ListQueueStatesRequest) (*ListQueueStatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListQueueStates not implemented")
+}
+func (UnimplementedWorkerServiceServer) SetTypeLimit(context.Context, *SetTypeLimitRequest) (*TypeLimit, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTypeLimit not implemented")
+}
+func (UnimplementedWorkerServiceServer) ListTypeLimits(context.Context, *ListTypeLimitsRequest) (*ListTypeLimitsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTypeLimits not implemented")
+}
+func (UnimplementedWorkerServiceServer) StreamJobLogs(*StreamJobLogsRequest, grpc.ServerStreamingServer[JobLogEntry]) error {
+	return status.Error(codes.Unimplemented, "method StreamJobLogs not implemented")
+}
 func (UnimplementedWorkerServiceServer) mustEmbedUnimplementedWorkerServiceServer() {}
 func (UnimplementedWorkerServiceServer) testEmbeddedByValue()                       {}
 
@@ -206,6 +394,186 @@ func _WorkerService_NotifyJobFailed_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkerService_ExecuteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).ExecuteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_ExecuteJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).ExecuteJob(ctx, req.(*ExecuteJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_SubmitJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WorkerServiceServer).SubmitJobs(&grpc.GenericServerStream[SubmitJobRequest, SubmitJobsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WorkerService_SubmitJobsServer = grpc.ClientStreamingServer[SubmitJobRequest, SubmitJobsResponse]
+
+func _WorkerService_SubmitWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).SubmitWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_SubmitWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).SubmitWorkflow(ctx, req.(*SubmitWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_GetWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).GetWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_GetWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).GetWorkflow(ctx, req.(*GetWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_PauseQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).PauseQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_PauseQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).PauseQueue(ctx, req.(*QueueControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_ResumeQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).ResumeQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_ResumeQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).ResumeQueue(ctx, req.(*QueueControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_DrainQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).DrainQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_DrainQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).DrainQueue(ctx, req.(*QueueControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_ListQueueStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQueueStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).ListQueueStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_ListQueueStates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).ListQueueStates(ctx, req.(*ListQueueStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_SetTypeLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTypeLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).SetTypeLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_SetTypeLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).SetTypeLimit(ctx, req.(*SetTypeLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_ListTypeLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTypeLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServiceServer).ListTypeLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerService_ListTypeLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServiceServer).ListTypeLimits(ctx, req.(*ListTypeLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkerService_StreamJobLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamJobLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkerServiceServer).StreamJobLogs(m, &grpc.GenericServerStream[StreamJobLogsRequest, JobLogEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WorkerService_StreamJobLogsServer = grpc.ServerStreamingServer[JobLogEntry]
+
 // WorkerService_ServiceDesc is the grpc.ServiceDesc for WorkerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,7 +597,54 @@ var WorkerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "NotifyJobFailed",
 			Handler:    _WorkerService_NotifyJobFailed_Handler,
 		},
+		{
+			MethodName: "ExecuteJob",
+			Handler:    _WorkerService_ExecuteJob_Handler,
+		},
+		{
+			MethodName: "SubmitWorkflow",
+			Handler:    _WorkerService_SubmitWorkflow_Handler,
+		},
+		{
+			MethodName: "GetWorkflow",
+			Handler:    _WorkerService_GetWorkflow_Handler,
+		},
+		{
+			MethodName: "PauseQueue",
+			Handler:    _WorkerService_PauseQueue_Handler,
+		},
+		{
+			MethodName: "ResumeQueue",
+			Handler:    _WorkerService_ResumeQueue_Handler,
+		},
+		{
+			MethodName: "DrainQueue",
+			Handler:    _WorkerService_DrainQueue_Handler,
+		},
+		{
+			MethodName: "ListQueueStates",
+			Handler:    _WorkerService_ListQueueStates_Handler,
+		},
+		{
+			MethodName: "SetTypeLimit",
+			Handler:    _WorkerService_SetTypeLimit_Handler,
+		},
+		{
+			MethodName: "ListTypeLimits",
+			Handler:    _WorkerService_ListTypeLimits_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubmitJobs",
+			Handler:       _WorkerService_SubmitJobs_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamJobLogs",
+			Handler:       _WorkerService_StreamJobLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/jobqueue.proto",
 }